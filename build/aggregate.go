@@ -52,7 +52,7 @@ func (a aggrExpression) build(b *builder) {
 		if i > 0 {
 			b.write(", ")
 		}
-		expr.build(b)
+		buildOperand(b, expr, false)
 	}
 	if a.orderby != nil {
 		b.write(" ORDER BY ")
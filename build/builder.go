@@ -4,49 +4,184 @@ import (
 	"bytes"
 	"database/sql/driver"
 	"fmt"
+	"reflect"
+	"strings"
 	"time"
 )
 
 type builder struct {
-	buf    bytes.Buffer
-	params []interface{}
+	buf     bytes.Buffer
+	params  []interface{}
+	dialect Dialect
+	errs    []error
+	binds   []bindSlot
+	inline  bool
+	pretty  bool
+	depth   int
+
+	// lenient is set by Build, which writes empty lists as () instead of
+	// reporting an error, as it did before BuildErr existed.
+	lenient bool
+}
+
+// A bindSlot is the position of the parameters bound by a Bind or BindArray
+// expression.
+type bindSlot struct {
+	start, n int
+	list     bool
+	nested   bool // list has nested lists
+	array    bool
+}
+
+func buildStmt(stmt Expression, d Dialect) (string, []interface{}, error) {
+	return buildWith(&builder{dialect: d}, stmt)
+}
+
+func buildWith(b *builder, stmt Expression) (string, []interface{}, error) {
+	stmt.build(b)
+	if b.errs != nil {
+		return "", nil, &BuildError{Errs: b.errs}
+	}
+	return b.buf.String(), b.params, nil
+}
+
+func mustBuildStmt(stmt Expression, d Dialect) (string, []interface{}) {
+	query, args, err := buildWith(&builder{dialect: d, lenient: true}, stmt)
+	if err != nil {
+		panic(err)
+	}
+	return query, args
 }
 
+// bind binds value. Lists, as reported by isList, are bound as lists of
+// parameters.
 func (b *builder) bind(value interface{}) {
-	switch v := value.(type) {
-	case bool, float64, int, int64, string, []byte, time.Time, driver.Valuer, nil:
-		b.params = append(b.params, value)
-		fmt.Fprintf(&b.buf, "$%d", len(b.params))
-	case []int64:
-		b.write("(")
-		for i := range v {
-			if i > 0 {
-				b.write(", ")
-			}
-			b.bind(v[i])
+	if !isList(value) {
+		if !isBindable(value) {
+			b.errorf("don't know how to bind value %#v (%T)", value, value)
+			return
 		}
-		b.write(")")
-	case []string:
-		b.write("(")
-		for i := range v {
-			if i > 0 {
-				b.write(", ")
-			}
-			b.bind(v[i])
-		}
-		b.write(")")
-	case []interface{}:
-		b.write("(")
-		for i := range v {
-			if i > 0 {
-				b.write(", ")
-			}
-			b.bind(v[i])
+		b.param(value)
+		return
+	}
+
+	v := reflect.ValueOf(value)
+	if v.Len() == 0 && !b.lenient {
+		b.errorf("can't bind empty list %T", value)
+		return
+	}
+	b.write("(")
+	for i := 0; i < v.Len(); i++ {
+		if i > 0 {
+			b.write(", ")
 		}
-		b.write(")")
-	default:
-		panic(fmt.Sprintf("don't know how to bind value %#v (%T)", v, v))
+		b.bind(v.Index(i).Interface())
+	}
+	b.write(")")
+}
+
+// isList reports whether value is a slice bound as a list of parameters, i.e.
+// any slice except byte slices and driver.Valuer implementations.
+func isList(value interface{}) bool {
+	switch value.(type) {
+	case []byte, driver.Valuer, nil:
+		return false
+	}
+	t := reflect.TypeOf(value)
+	return t.Kind() == reflect.Slice && t.Elem().Kind() != reflect.Uint8
+}
+
+// isBindable reports whether value can be bound as a single parameter.
+func isBindable(value interface{}) bool {
+	switch value.(type) {
+	case bool, float64, int, int64, string, []byte, time.Time, driver.Valuer, nil:
+		return true
+	}
+	switch reflect.TypeOf(value).Kind() {
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64,
+		reflect.String, reflect.Ptr:
+		return true
+	case reflect.Slice:
+		return reflect.TypeOf(value).Elem().Kind() == reflect.Uint8
+	}
+	return false
+}
+
+// param binds value as a single parameter.
+func (b *builder) param(value interface{}) {
+	if b.inline {
+		b.writeLiteral(value)
+		return
+	}
+	b.params = append(b.params, value)
+	b.write(b.dialect.Placeholder(len(b.params)))
+}
+
+func (b *builder) quoteIdent(s string) string {
+	if strings.IndexByte(s, 0) >= 0 {
+		b.errorf("identifier %q contains a NUL byte", s)
+	}
+	return b.dialect.QuoteIdent(s)
+}
+
+func (b *builder) quoteString(s string) string {
+	if strings.IndexByte(s, 0) >= 0 {
+		b.errorf("string %q contains a NUL byte", s)
+	}
+	return b.dialect.QuoteString(s)
+}
+
+func (b *builder) errorf(format string, args ...interface{}) {
+	b.errs = append(b.errs, fmt.Errorf(format, args...))
+}
+
+// clause separates two clauses with a space, or with a new line in pretty
+// mode.
+func (b *builder) clause() {
+	if b.pretty {
+		b.newline()
+		return
+	}
+	b.write(" ")
+}
+
+// newline writes a new line and indents it.
+func (b *builder) newline() {
+	b.write("\n")
+	for i := 0; i < b.depth; i++ {
+		b.write("  ")
+	}
+}
+
+// indent separates two parts of an expression with a space, or with a new
+// indented line in pretty mode.
+func (b *builder) indent() {
+	if b.pretty {
+		b.depth++
+		b.newline()
+		b.depth--
+		return
+	}
+	b.write(" ")
+}
+
+// subquery builds the query expr in parentheses, on indented lines in pretty
+// mode.
+func (b *builder) subquery(expr Expression) {
+	b.write("(")
+	if b.pretty {
+		b.depth++
+		b.newline()
+		expr.build(b)
+		b.depth--
+		b.newline()
+	} else {
+		expr.build(b)
 	}
+	b.write(")")
 }
 
 func (b *builder) write(s string) {
@@ -0,0 +1,56 @@
+package build
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"testing"
+)
+
+type valuer int
+
+func (v valuer) Value() (driver.Value, error) { return int64(v), nil }
+
+type status string
+
+func TestBind(t *testing.T) {
+	for _, tt := range []struct {
+		expr Expression
+		out  string
+		args []interface{}
+	}{{
+		expr: Ident("id").In(Bind([]int{1, 2})),
+		out:  `SELECT "id" IN ($1, $2)`,
+		args: []interface{}{1, 2},
+	}, {
+		expr: Ident("id").In(Bind([]int32{1, 2})),
+		out:  `SELECT "id" IN ($1, $2)`,
+		args: []interface{}{int32(1), int32(2)},
+	}, {
+		expr: Ident("id").In(Bind([]valuer{1, 2})),
+		out:  `SELECT "id" IN ($1, $2)`,
+		args: []interface{}{valuer(1), valuer(2)},
+	}, {
+		expr: Ident("status").In(Bind([]status{"todo", "done"})),
+		out:  `SELECT "status" IN ($1, $2)`,
+		args: []interface{}{status("todo"), status("done")},
+	}, {
+		expr: Ident("data").Equal(Bind([]byte("hello"))),
+		out:  `SELECT "data" = $1`,
+		args: []interface{}{[]byte("hello")},
+	}, {
+		expr: Ident("id").Equal(Any(BindArray([]int64{1, 2, 3}))),
+		out:  `SELECT "id" = ANY($1)`,
+		args: []interface{}{[]int64{1, 2, 3}},
+	}} {
+		t.Run(tt.out, func(t *testing.T) {
+			out, args := Select(tt.expr).Build()
+			assertf(t, out == tt.out, "expected %q, got %q", tt.out, out)
+			assertf(t, reflect.DeepEqual(args, tt.args), "expected %#v, got %#v", tt.args, args)
+		})
+	}
+
+	_, _, err := Select(Ident("id").In(Bind([]int{}))).BuildErr()
+	assertf(t, err != nil && err.Error() == "build: can't bind empty list []int", "expected an error, got %v", err)
+	out, _ := Select(Ident("id").In(Bind([]int{}))).Build()
+	assertf(t, out == `SELECT "id" IN ()`, "got %q", out)
+}
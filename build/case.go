@@ -31,14 +31,17 @@ func (c CaseExpr) Else(result Expression) *InfixExpr {
 func (c CaseExpr) build(b *builder) {
 	b.write("CASE")
 	for i := range c.whens {
-		b.write(" WHEN ")
-		c.whens[i].condition.build(b)
+		b.indent()
+		b.write("WHEN ")
+		buildOperand(b, c.whens[i].condition, false)
 		b.write(" THEN ")
-		c.whens[i].result.build(b)
+		buildOperand(b, c.whens[i].result, false)
 	}
 	if c.elseresult != nil {
-		b.write(" ELSE ")
-		c.elseresult.build(b)
+		b.indent()
+		b.write("ELSE ")
+		buildOperand(b, c.elseresult, false)
 	}
-	b.write(" END")
+	b.clause()
+	b.write("END")
 }
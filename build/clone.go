@@ -0,0 +1,107 @@
+package build
+
+// Clone returns a copy of s, whose clauses can be set without modifying s.
+// The joins of the FROM clause are copied too, so that they can be modified
+// with their On and Using methods. Other expressions and subqueries are
+// shared with s, and must not be modified through the copy.
+func (s *SelectStmt) Clone() *SelectStmt {
+	c := *s
+	c.ctes = s.ctes.Clone()
+	c.distincton = clone(s.distincton)
+	c.exprs = clone(s.exprs)
+	c.from = cloneFromItems(s.from)
+	c.groupby = clone(s.groupby)
+	c.windows = clone(s.windows)
+	c.unions = clone(s.unions)
+	c.orderby = clone(s.orderby)
+	c.locking = clone(s.locking)
+	return &c
+}
+
+// Clone returns a copy of c, like SelectStmt.Clone.
+func (c *CompoundStmt) Clone() *CompoundStmt {
+	cc := *c
+	cc.ctes = c.ctes.Clone()
+	cc.stmts = clone(c.stmts)
+	cc.orderby = clone(c.orderby)
+	return &cc
+}
+
+// Clone returns a copy of stmt, like SelectStmt.Clone.
+func (stmt *InsertStmt) Clone() *InsertStmt {
+	c := *stmt
+	c.ctes = stmt.ctes.Clone()
+	c.columns = clone(stmt.columns)
+	c.returning = clone(stmt.returning)
+	return &c
+}
+
+// Clone returns a copy of stmt, like SelectStmt.Clone.
+func (stmt *UpdateStmt) Clone() *UpdateStmt {
+	c := *stmt
+	c.ctes = stmt.ctes.Clone()
+	c.assignments = clone(stmt.assignments)
+	c.from = cloneFromItems(stmt.from)
+	c.returning = clone(stmt.returning)
+	return &c
+}
+
+// Clone returns a copy of stmt, like SelectStmt.Clone.
+func (stmt *DeleteStmt) Clone() *DeleteStmt {
+	c := *stmt
+	c.ctes = stmt.ctes.Clone()
+	c.using = cloneFromItems(stmt.using)
+	c.returning = clone(stmt.returning)
+	return &c
+}
+
+// Clone returns a copy of stmt, like SelectStmt.Clone.
+func (stmt *MergeStmt) Clone() *MergeStmt {
+	c := *stmt
+	c.ctes = stmt.ctes.Clone()
+	c.using = cloneFromItem(stmt.using)
+	c.whens = clone(stmt.whens)
+	return &c
+}
+
+// Clone returns a copy of e, which can be modified without modifying e.
+// The statements of the Common Table Expressions are not copied. Clone
+// returns nil if e is nil.
+func (e *CTEs) Clone() *CTEs {
+	if e == nil {
+		return nil
+	}
+	c := *e
+	c.ctes = clone(e.ctes)
+	return &c
+}
+
+// cloneFromItems returns a copy of items, with their joins copied.
+func cloneFromItems[S ~[]Expression](items S) S {
+	c := clone(items)
+	for i := range c {
+		c[i] = cloneFromItem(c[i])
+	}
+	return c
+}
+
+// cloneFromItem returns a copy of the FROM item expr if it is a join, which
+// is modified in place by its On and Using methods.
+func cloneFromItem(expr Expression) Expression {
+	j, ok := expr.(*joinExpr)
+	if !ok {
+		return expr
+	}
+	c := *j
+	c.left = cloneFromItem(j.left)
+	c.right = cloneFromItem(j.right)
+	c.using = clone(j.using)
+	return &c
+}
+
+func clone[S ~[]E, E any](s S) S {
+	if s == nil {
+		return nil
+	}
+	return append(make(S, 0, len(s)), s...)
+}
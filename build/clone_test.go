@@ -0,0 +1,141 @@
+package build
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestClone(t *testing.T) {
+	base := Select(Columns("id", "name")...).
+		From(Ident("users")).
+		Where(Ident("org_id").Equal(Bind(1)))
+	const baseout = `SELECT "id", "name" FROM "users" WHERE "org_id" = $1`
+
+	count := base.Clone()
+	count.exprs[0] = CallExpr("count", Star)
+	count.exprs = count.exprs[:1]
+
+	page := base.Clone().
+		AndWhere(Ident("name").ILike(Bind("foo%"))).
+		OrderBy(Ident("id")).
+		Limit(Bind(10))
+
+	for _, tt := range []struct {
+		stmt *SelectStmt
+		out  string
+	}{{
+		stmt: base,
+		out:  baseout,
+	}, {
+		stmt: count,
+		out:  `SELECT count(*) FROM "users" WHERE "org_id" = $1`,
+	}, {
+		stmt: page,
+		out:  `SELECT "id", "name" FROM "users" WHERE "org_id" = $1 AND "name" ILIKE $2 ORDER BY "id" LIMIT $3`,
+	}} {
+		out, _ := tt.stmt.Build()
+		assertf(t, out == tt.out, "expected %q, got %q", tt.out, out)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			base.Clone().AndWhere(Ident("id").Equal(Bind(i))).Build()
+			out, _ := base.Build()
+			assertf(t, out == baseout, "expected %q, got %q", baseout, out)
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestCloneJoin(t *testing.T) {
+	base := Select(Star).From(FromItem(Ident("a")).
+		Join(Ident("b")).On(Ident("a.id").Equal(Ident("b.a_id"))).
+		Join(Ident("c")).Using("id"))
+	const baseout = `SELECT * FROM "a" JOIN "b" ON "a"."id" = "b"."a_id" JOIN "c" USING ("id")`
+
+	c := base.Clone()
+	join := c.from[0].(*joinExpr)
+	join.Using("c_id")
+	join.left.(*joinExpr).On(Ident("a.id").Equal(Ident("b.id")))
+	out, _ := c.Build()
+	expected := `SELECT * FROM "a" JOIN "b" ON "a"."id" = "b"."id" JOIN "c" USING ("c_id")`
+	assertf(t, out == expected, "expected %q, got %q", expected, out)
+
+	out, _ = base.Build()
+	assertf(t, out == baseout, "expected %q, got %q", baseout, out)
+
+	update := Update("t").Set(Assign("x", Int(1))).From(FromItem(Ident("a")).Join(Ident("b")).On(Bool(true)))
+	updateclone := update.Clone()
+	updateclone.from[0].(*joinExpr).On(Bool(false))
+	out, _ = update.Build()
+	expected = `UPDATE "t" SET "x" = 1 FROM "a" JOIN "b" ON true`
+	assertf(t, out == expected, "expected %q, got %q", expected, out)
+}
+
+func TestCloneStatements(t *testing.T) {
+	ctes := With("a", Select(Int(1)))
+	update := ctes.Update("t").Set(Assign("foo", Bind(1)))
+	updateclone := update.Clone()
+	updateclone.ctes.With("b", Select(Int(2)))
+	updateclone.Set(Assign("bar", Bind(2))).Where(Ident("id").Equal(Bind(3)))
+
+	insert := InsertInto("t", "foo").Values(Bind(1))
+	insertclone := insert.Clone().Returning(Star)
+	insertclone.columns[0] = Ident("bar")
+
+	del := DeleteFrom("t").Using(Ident("u"))
+	delclone := del.Clone().AndWhere(Ident("t.id").Equal(Ident("u.id")))
+	delclone.using[0] = Ident("v")
+
+	merge := MergeInto("t").Using(Ident("u")).On(Ident("t.id").Equal(Ident("u.id"))).When(WhenMatched().ThenDelete())
+	mergeclone := merge.Clone().When(WhenNotMatched().ThenDoNothing())
+	mergeclone.whens[0] = WhenMatched().ThenDoNothing()
+
+	compound := With("a", Select(Int(1))).Union(Select(Int(1)), Select(Int(2)))
+	compoundclone := compound.Clone().OrderBy(Int(1))
+	compoundclone.stmts[1] = Select(Int(3))
+	compoundclone.ctes.With("b", Select(Int(2)))
+
+	for _, tt := range []struct {
+		stmt interface {
+			Build() (string, []interface{})
+		}
+		out string
+	}{{
+		stmt: update,
+		out:  `WITH "a" AS ( SELECT 1 ) UPDATE "t" SET "foo" = $1`,
+	}, {
+		stmt: updateclone,
+		out:  `WITH "a" AS ( SELECT 1 ), "b" AS ( SELECT 2 ) UPDATE "t" SET "bar" = $1 WHERE "id" = $2`,
+	}, {
+		stmt: insert,
+		out:  `INSERT INTO "t" ("foo") VALUES ($1)`,
+	}, {
+		stmt: insertclone,
+		out:  `INSERT INTO "t" ("bar") VALUES ($1) RETURNING *`,
+	}, {
+		stmt: del,
+		out:  `DELETE FROM "t" USING "u"`,
+	}, {
+		stmt: delclone,
+		out:  `DELETE FROM "t" USING "v" WHERE "t"."id" = "u"."id"`,
+	}, {
+		stmt: merge,
+		out:  `MERGE INTO "t" USING "u" ON "t"."id" = "u"."id" WHEN MATCHED THEN DELETE`,
+	}, {
+		stmt: mergeclone,
+		out:  `MERGE INTO "t" USING "u" ON "t"."id" = "u"."id" WHEN MATCHED THEN DO NOTHING WHEN NOT MATCHED THEN DO NOTHING`,
+	}, {
+		stmt: compound,
+		out:  `WITH "a" AS ( SELECT 1 ) (SELECT 1) UNION (SELECT 2)`,
+	}, {
+		stmt: compoundclone,
+		out:  `WITH "a" AS ( SELECT 1 ), "b" AS ( SELECT 2 ) (SELECT 1) UNION (SELECT 3) ORDER BY 1`,
+	}} {
+		out, _ := tt.stmt.Build()
+		assertf(t, out == tt.out, "expected %q, got %q", tt.out, out)
+	}
+}
@@ -0,0 +1,146 @@
+package build
+
+// Union returns a new UNION compound statement.
+func Union(stmts ...Expression) *CompoundStmt {
+	return &CompoundStmt{op: "UNION", stmts: stmts}
+}
+
+// UnionAll returns a new UNION ALL compound statement.
+func UnionAll(stmts ...Expression) *CompoundStmt {
+	return &CompoundStmt{op: "UNION ALL", stmts: stmts}
+}
+
+// Intersect returns a new INTERSECT compound statement.
+func Intersect(stmts ...Expression) *CompoundStmt {
+	return &CompoundStmt{op: "INTERSECT", stmts: stmts}
+}
+
+// IntersectAll returns a new INTERSECT ALL compound statement.
+func IntersectAll(stmts ...Expression) *CompoundStmt {
+	return &CompoundStmt{op: "INTERSECT ALL", stmts: stmts}
+}
+
+// Except returns a new EXCEPT compound statement.
+func Except(stmts ...Expression) *CompoundStmt {
+	return &CompoundStmt{op: "EXCEPT", stmts: stmts}
+}
+
+// ExceptAll returns a new EXCEPT ALL compound statement.
+func ExceptAll(stmts ...Expression) *CompoundStmt {
+	return &CompoundStmt{op: "EXCEPT ALL", stmts: stmts}
+}
+
+// OrderBy adds a ORDER BY clause.
+func (c *CompoundStmt) OrderBy(exprs ...Expression) *CompoundStmt {
+	c.orderby = exprs
+	return c
+}
+
+// Limit adds a LIMIT clause.
+func (c *CompoundStmt) Limit(count Expression) *CompoundStmt {
+	c.limit = &limit{Expression: count}
+	return c
+}
+
+// Offset adds a OFFSET clause.
+func (c *CompoundStmt) Offset(start Expression) *CompoundStmt {
+	c.offset = &offset{Expression: start}
+	return c
+}
+
+// Build builds c and its parameters. Build panics if c is invalid.
+func (c *CompoundStmt) Build() (string, []interface{}) {
+	return mustBuildStmt(c, Postgres)
+}
+
+// BuildFor builds c and its parameters for the dialect d. BuildFor panics
+// if c is invalid.
+func (c *CompoundStmt) BuildFor(d Dialect) (string, []interface{}) {
+	return mustBuildStmt(c, d)
+}
+
+// BuildErr builds c and its parameters. If c is invalid, BuildErr
+// returns a *BuildError.
+func (c *CompoundStmt) BuildErr() (string, []interface{}, error) {
+	return buildStmt(c, Postgres)
+}
+
+// BuildErrFor builds c and its parameters for the dialect d. If c is
+// invalid, BuildErrFor returns a *BuildError.
+func (c *CompoundStmt) BuildErrFor(d Dialect) (string, []interface{}, error) {
+	return buildStmt(c, d)
+}
+
+func (c *CompoundStmt) build(b *builder) {
+	if len(c.stmts) < 2 {
+		b.errorf("%s statement has %d statements, must have at least 2", c.op, len(c.stmts))
+	}
+	if (c.op == "INTERSECT ALL" || c.op == "EXCEPT ALL") && !b.dialect.Supports(IntersectExceptAll) {
+		b.errorf("%s is not supported by the dialect", c.op)
+	}
+	if c.ctes != nil {
+		c.ctes.build(b)
+	}
+	for i := range c.stmts {
+		if i > 0 {
+			b.clause()
+			b.write(c.op)
+			b.clause()
+		}
+		buildCompoundBranch(b, c.stmts[i])
+	}
+
+	if c.orderby != nil {
+		b.clause()
+		c.orderby.build(b)
+	}
+
+	buildLimitOffset(b, c.orderby != nil, c.limit, c.offset)
+}
+
+// A CompoundStmt is a compound statement, combining the results of several
+// statements with UNION, INTERSECT or EXCEPT. Each statement is
+// parenthesized, unless the dialect doesn't support it, and the ORDER BY,
+// LIMIT and OFFSET clauses apply to the whole result.
+type CompoundStmt struct {
+	ctes    *CTEs
+	op      string
+	stmts   []Expression
+	orderby orderby
+	limit   *limit
+	offset  *offset
+}
+
+// buildCompoundBranch builds a statement of a compound statement. If the
+// dialect doesn't support parenthesized statements, like SQLite, a statement
+// which has its own clauses applying to its result is built as a subquery in
+// a FROM clause.
+func buildCompoundBranch(b *builder, stmt Expression) {
+	if b.dialect.Supports(ParenthesizedCompounds) {
+		b.subquery(stmt)
+		return
+	}
+	switch s := stmt.(type) {
+	case *CompoundStmt:
+	case *SelectStmt:
+		if s.ctes == nil && s.unions == nil && s.orderby == nil && s.limit == nil && s.offset == nil {
+			stmt.build(b)
+			return
+		}
+	default:
+		stmt.build(b)
+		return
+	}
+	b.write("SELECT * FROM ")
+	b.subquery(stmt)
+}
+
+// isQuery reports whether expr is a query, which must be parenthesized when
+// used as a FROM item or in an expression.
+func isQuery(expr Expression) bool {
+	switch expr.(type) {
+	case *SelectStmt, *CompoundStmt, *ValuesTableExpr:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,111 @@
+package build
+
+import "testing"
+
+func TestCompound(t *testing.T) {
+	for _, tt := range []struct {
+		stmt *CompoundStmt
+		out  string
+		args []interface{}
+	}{{
+		stmt: Union(
+			Select(Ident("name")).From(Ident("distributors")).Where(Ident("name").Op("LIKE", Bind("W%"))),
+			Select(Ident("name")).From(Ident("actors")).Where(Ident("name").Op("LIKE", Bind("W%"))),
+		),
+		out:  `(SELECT "name" FROM "distributors" WHERE "name" LIKE $1) UNION (SELECT "name" FROM "actors" WHERE "name" LIKE $2)`,
+		args: []interface{}{"W%", "W%"},
+	}, {
+		stmt: UnionAll(
+			Select(Ident("id")).From(Ident("a")),
+			Select(Ident("id")).From(Ident("b")).OrderBy(Ident("id")).Limit(Int(1)),
+			Select(Ident("id")).From(Ident("c")),
+		).OrderBy(Order(Ident("id"), Desc)).Limit(Bind(10)).Offset(Bind(20)),
+		out:  `(SELECT "id" FROM "a") UNION ALL (SELECT "id" FROM "b" ORDER BY "id" LIMIT 1) UNION ALL (SELECT "id" FROM "c") ORDER BY "id" DESC LIMIT $1 OFFSET $2`,
+		args: []interface{}{10, 20},
+	}, {
+		stmt: Except(
+			Intersect(Select(Ident("id")).From(Ident("a")), Select(Ident("id")).From(Ident("b"))),
+			Select(Ident("id")).From(Ident("c")),
+		),
+		out: `((SELECT "id" FROM "a") INTERSECT (SELECT "id" FROM "b")) EXCEPT (SELECT "id" FROM "c")`,
+	}, {
+		stmt: IntersectAll(Select(Int(1)), Select(Int(2))),
+		out:  `(SELECT 1) INTERSECT ALL (SELECT 2)`,
+	}, {
+		stmt: ExceptAll(Select(Int(1)), Select(Int(2))),
+		out:  `(SELECT 1) EXCEPT ALL (SELECT 2)`,
+	}, {
+		stmt: With("t", Select(Ident("id")).From(Ident("a")).Where(Ident("x").Equal(Bind(1)))).
+			Union(Select(Ident("id")).From(Ident("t")), Select(Ident("id")).From(Ident("b"))).
+			OrderBy(Ident("id")),
+		out:  `WITH "t" AS ( SELECT "id" FROM "a" WHERE "x" = $1 ) (SELECT "id" FROM "t") UNION (SELECT "id" FROM "b") ORDER BY "id"`,
+		args: []interface{}{1},
+	}} {
+		t.Run(tt.out, func(t *testing.T) {
+			out, args := tt.stmt.Build()
+			assertf(t, out == tt.out, "expected %q, got %q", tt.out, out)
+			assertf(t, len(args) == len(tt.args), "expected %d args, got %d", len(tt.args), len(args))
+			minlen := len(args)
+			if len(tt.args) < minlen {
+				minlen = len(tt.args)
+			}
+			for i := 0; i < minlen; i++ {
+				assertf(t, args[i] == tt.args[i], "expected %#v, got %#v", tt.args[i], args[i])
+			}
+		})
+	}
+}
+
+func TestCompoundFromItem(t *testing.T) {
+	out, _ := Select(CallExpr("count", Star)).
+		From(FromExpr(Union(Select(Ident("id")).From(Ident("a")), Select(Ident("id")).From(Ident("b")))).As("ids")).
+		Build()
+	expected := `SELECT count(*) FROM ((SELECT "id" FROM "a") UNION (SELECT "id" FROM "b")) AS "ids"`
+	assertf(t, out == expected, "expected %q, got %q", expected, out)
+
+	_, _, err := Union(Select(Int(1))).BuildErr()
+	assertf(t, err != nil, "expected an error")
+}
+
+func TestCompoundSQLite(t *testing.T) {
+	for _, tt := range []struct {
+		stmt Expression
+		out  string
+	}{{
+		stmt: UnionAll(Select(Int(1)), Select(Int(2))),
+		out:  `SELECT 1 UNION ALL SELECT 2`,
+	}, {
+		stmt: Union(
+			Select(Ident("id")).From(Ident("a")),
+			Select(Ident("id")).From(Ident("b")).OrderBy(Ident("id")).Limit(Int(1)),
+			Intersect(Select(Ident("id")).From(Ident("c")), Select(Ident("id")).From(Ident("d"))),
+		).OrderBy(Ident("id")),
+		out: `SELECT "id" FROM "a" UNION SELECT * FROM (SELECT "id" FROM "b" ORDER BY "id" LIMIT 1) UNION SELECT * FROM (SELECT "id" FROM "c" INTERSECT SELECT "id" FROM "d") ORDER BY "id"`,
+	}, {
+		stmt: WithRecursive("t", UnionAll(
+			Select(Int(1)),
+			Select(Ident("n").Op("+", Int(1))).From(Ident("t")).Where(Ident("n").LessThan(Int(100))),
+		)).Columns("n").Select(CallExpr("sum", Ident("n"))).From(Ident("t")),
+		out: `WITH RECURSIVE "t"("n") AS ( SELECT 1 UNION ALL SELECT "n" + 1 FROM "t" WHERE "n" < 100 ) SELECT sum("n") FROM "t"`,
+	}, {
+		stmt: Union(
+			Select(Int(1)),
+			With("t", Select(Int(2))).Union(Select(Star).From(Ident("t")), Select(Int(3))),
+		),
+		out: `SELECT 1 UNION SELECT * FROM (WITH "t" AS ( SELECT 2 ) SELECT * FROM "t" UNION SELECT 3)`,
+	}} {
+		t.Run(tt.out, func(t *testing.T) {
+			out, _, err := buildStmt(tt.stmt, SQLite)
+			assertf(t, err == nil, "expected no error, got %v", err)
+			assertf(t, out == tt.out, "expected %q, got %q", tt.out, out)
+		})
+	}
+
+	for _, stmt := range []*CompoundStmt{
+		IntersectAll(Select(Int(1)), Select(Int(2))),
+		ExceptAll(Select(Int(1)), Select(Int(2))),
+	} {
+		_, _, err := stmt.BuildErrFor(SQLite)
+		assertf(t, err != nil, "expected an error")
+	}
+}
@@ -2,22 +2,63 @@ package build
 
 // With returns a new CTEs.
 func With(alias string, stmt Expression) *CTEs {
-	return &CTEs{ctes: []cte{{alias: alias, stmt: stmt}}}
+	return &CTEs{ctes: []cte{{alias: identifier(alias), stmt: stmt}}}
+}
+
+// WithRecursive returns a new CTEs with the RECURSIVE keyword.
+func WithRecursive(alias string, stmt Expression) *CTEs {
+	return &CTEs{recursive: true, ctes: []cte{{alias: identifier(alias), stmt: stmt}}}
 }
 
 // A CTEs is a list of Common Table Expressions.
 type CTEs struct {
-	ctes []cte
+	recursive bool
+	ctes      []cte
 }
 
 type cte struct {
-	alias string
-	stmt  Expression
+	alias        identifier
+	columns      []Expression
+	materialized *bool
+	stmt         Expression
 }
 
 // With appends a new Common Table Expressions to e.
 func (e *CTEs) With(alias string, stmt Expression) *CTEs {
-	e.ctes = append(e.ctes, cte{alias: alias, stmt: stmt})
+	e.ctes = append(e.ctes, cte{alias: identifier(alias), stmt: stmt})
+	return e
+}
+
+// WithRecursive appends a new Common Table Expressions to e, and adds the
+// RECURSIVE keyword.
+func (e *CTEs) WithRecursive(alias string, stmt Expression) *CTEs {
+	e.recursive = true
+	return e.With(alias, stmt)
+}
+
+// Columns sets the column names of the last Common Table Expression of e.
+func (e *CTEs) Columns(names ...string) *CTEs {
+	last := &e.ctes[len(e.ctes)-1]
+	last.columns = make([]Expression, 0, len(names))
+	for i := range names {
+		last.columns = append(last.columns, identifier(names[i]))
+	}
+	return e
+}
+
+// Materialized adds the MATERIALIZED keyword to the last Common Table
+// Expression of e.
+func (e *CTEs) Materialized() *CTEs {
+	materialized := true
+	e.ctes[len(e.ctes)-1].materialized = &materialized
+	return e
+}
+
+// NotMaterialized adds the NOT MATERIALIZED keyword to the last Common Table
+// Expression of e.
+func (e *CTEs) NotMaterialized() *CTEs {
+	materialized := false
+	e.ctes[len(e.ctes)-1].materialized = &materialized
 	return e
 }
 
@@ -26,21 +67,92 @@ func (e *CTEs) Select(exprs ...Expression) *SelectStmt {
 	return &SelectStmt{ctes: e, exprs: exprs}
 }
 
+// InsertInto starts a new insert statement attached to e.
+func (e *CTEs) InsertInto(table string, columns ...string) *InsertStmt {
+	stmt := InsertInto(table, columns...)
+	stmt.ctes = e
+	return stmt
+}
+
 // Update starts a new update statement attached to e.
 func (e *CTEs) Update(table string) *UpdateStmt {
 	return &UpdateStmt{ctes: e, table: Ident(table)}
 }
 
+// DeleteFrom starts a new delete statement attached to e.
+func (e *CTEs) DeleteFrom(table string) *DeleteStmt {
+	return &DeleteStmt{ctes: e, table: Ident(table)}
+}
+
+// MergeInto starts a new merge statement attached to e.
+func (e *CTEs) MergeInto(table string) *MergeStmt {
+	return &MergeStmt{ctes: e, table: Ident(table)}
+}
+
+// Union starts a new UNION compound statement attached to e.
+func (e *CTEs) Union(stmts ...Expression) *CompoundStmt {
+	return &CompoundStmt{ctes: e, op: "UNION", stmts: stmts}
+}
+
+// UnionAll starts a new UNION ALL compound statement attached to e.
+func (e *CTEs) UnionAll(stmts ...Expression) *CompoundStmt {
+	return &CompoundStmt{ctes: e, op: "UNION ALL", stmts: stmts}
+}
+
+// Intersect starts a new INTERSECT compound statement attached to e.
+func (e *CTEs) Intersect(stmts ...Expression) *CompoundStmt {
+	return &CompoundStmt{ctes: e, op: "INTERSECT", stmts: stmts}
+}
+
+// IntersectAll starts a new INTERSECT ALL compound statement attached to e.
+func (e *CTEs) IntersectAll(stmts ...Expression) *CompoundStmt {
+	return &CompoundStmt{ctes: e, op: "INTERSECT ALL", stmts: stmts}
+}
+
+// Except starts a new EXCEPT compound statement attached to e.
+func (e *CTEs) Except(stmts ...Expression) *CompoundStmt {
+	return &CompoundStmt{ctes: e, op: "EXCEPT", stmts: stmts}
+}
+
+// ExceptAll starts a new EXCEPT ALL compound statement attached to e.
+func (e *CTEs) ExceptAll(stmts ...Expression) *CompoundStmt {
+	return &CompoundStmt{ctes: e, op: "EXCEPT ALL", stmts: stmts}
+}
+
 func (e *CTEs) build(b *builder) {
 	b.write("WITH ")
+	if e.recursive {
+		b.write("RECURSIVE ")
+	}
 	for i, cte := range e.ctes {
 		if i > 0 {
 			b.write(", ")
 		}
-		b.write(cte.alias)
-		b.write(" AS ( ")
-		cte.stmt.build(b)
-		b.write(" )")
+		cte.alias.build(b)
+		if cte.columns != nil {
+			b.write("(")
+			for i := range cte.columns {
+				if i > 0 {
+					b.write(", ")
+				}
+				cte.columns[i].build(b)
+			}
+			b.write(")")
+		}
+		b.write(" AS ")
+		if cte.materialized != nil {
+			if !*cte.materialized {
+				b.write("NOT ")
+			}
+			b.write("MATERIALIZED ")
+		}
+		if b.pretty {
+			b.subquery(cte.stmt)
+		} else {
+			b.write("( ")
+			cte.stmt.build(b)
+			b.write(" )")
+		}
 	}
-	b.write(" ")
+	b.clause()
 }
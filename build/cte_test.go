@@ -9,10 +9,63 @@ func TestCTE(t *testing.T) {
 		args []interface{}
 	}{{
 		stmt: With("z", Select(Int(1))).Select(Star).From(Ident("z")),
-		out:  `WITH z AS ( SELECT 1 ) SELECT * FROM "z"`,
+		out:  `WITH "z" AS ( SELECT 1 ) SELECT * FROM "z"`,
 	}, {
 		stmt: With("z", Select(Int(1))).With("y", Select(Int(2))).Select(Star).From(Ident("z"), Ident("y")),
-		out:  `WITH z AS ( SELECT 1 ), y AS ( SELECT 2 ) SELECT * FROM "z", "y"`,
+		out:  `WITH "z" AS ( SELECT 1 ), "y" AS ( SELECT 2 ) SELECT * FROM "z", "y"`,
+	}, {
+		stmt: WithRecursive("t", UnionAll(
+			Select(Int(1)),
+			Select(Ident("n").Op("+", Int(1))).From(Ident("t")).Where(Ident("n").LessThan(Int(100))),
+		)).Columns("n").Select(CallExpr("sum", Ident("n"))).From(Ident("t")),
+		out: `WITH RECURSIVE "t"("n") AS ( (SELECT 1) UNION ALL (SELECT "n" + 1 FROM "t" WHERE "n" < 100) ) SELECT sum("n") FROM "t"`,
+	}, {
+		stmt: With("w", Select(Star).From(Ident("big_table"))).Materialized().
+			With("v", Select(Star).From(Ident("other_table"))).NotMaterialized().
+			Select(Star).From(Ident("w"), Ident("v")),
+		out: `WITH "w" AS MATERIALIZED ( SELECT * FROM "big_table" ), "v" AS NOT MATERIALIZED ( SELECT * FROM "other_table" ) SELECT * FROM "w", "v"`,
+	}, {
+		stmt: With("moved_rows", DeleteFrom("products").
+			Where(Ident("date").GreaterThanOrEqualTo(Bind("2010-10-01"))).
+			Returning(Star),
+		).Select(Star).From(Ident("moved_rows")),
+		out:  `WITH "moved_rows" AS ( DELETE FROM "products" WHERE "date" >= $1 RETURNING * ) SELECT * FROM "moved_rows"`,
+		args: []interface{}{"2010-10-01"},
+	}} {
+		t.Run(tt.out, func(t *testing.T) {
+			out, args := tt.stmt.Build()
+			assertf(t, out == tt.out, "expected %q, got %q", tt.out, out)
+			assertf(t, len(args) == len(tt.args), "expected %d args, got %d", len(tt.args), len(args))
+			minlen := len(args)
+			if len(tt.args) < minlen {
+				minlen = len(tt.args)
+			}
+			for i := 0; i < minlen; i++ {
+				assertf(t, args[i] == tt.args[i], "expected %#v, got %#v", tt.args[i], args[i])
+			}
+		})
+	}
+}
+
+func TestCTEStatements(t *testing.T) {
+	for _, tt := range []struct {
+		stmt interface {
+			Build() (string, []interface{})
+		}
+		out  string
+		args []interface{}
+	}{{
+		stmt: With("new_user", InsertInto("users", "name").Values(Bind("foo")).Returning(Ident("id"))).
+			InsertInto("memberships", "user_id", "org_id").
+			Query(Select(Ident("id"), Bind(1)).From(Ident("new_user"))),
+		out:  `WITH "new_user" AS ( INSERT INTO "users" ("name") VALUES ($1) RETURNING "id" ) INSERT INTO "memberships" ("user_id", "org_id") SELECT "id", $2 FROM "new_user"`,
+		args: []interface{}{"foo", 1},
+	}, {
+		stmt: With("t", Update("products").Set(Assign("price", Ident("price").Op("*", Bind(1.05)))).Returning(Star)).
+			InsertInto("products_log").
+			Query(Select(Star).From(Ident("t"))),
+		out:  `WITH "t" AS ( UPDATE "products" SET "price" = "price" * $1 RETURNING * ) INSERT INTO "products_log" SELECT * FROM "t"`,
+		args: []interface{}{1.05},
 	}} {
 		t.Run(tt.out, func(t *testing.T) {
 			out, args := tt.stmt.Build()
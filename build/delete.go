@@ -0,0 +1,109 @@
+package build
+
+// DeleteFrom returns a new DELETE statement.
+func DeleteFrom(table string) *DeleteStmt {
+	return &DeleteStmt{table: Ident(table)}
+}
+
+// Using adds a USING clause.
+func (stmt *DeleteStmt) Using(items ...Expression) *DeleteStmt {
+	stmt.using = items
+	return stmt
+}
+
+// Where adds a WHERE clause.
+func (stmt *DeleteStmt) Where(condition Expression) *DeleteStmt {
+	stmt.where = &where{Expression: condition}
+	return stmt
+}
+
+// AndWhere adds condition to the WHERE clause with the AND operator. If there
+// is no WHERE clause, AndWhere adds one. If condition is nil, AndWhere does
+// nothing.
+func (stmt *DeleteStmt) AndWhere(condition Expression) *DeleteStmt {
+	stmt.where = stmt.where.and("AND", condition)
+	return stmt
+}
+
+// OrWhere adds condition to the WHERE clause with the OR operator. If there
+// is no WHERE clause, OrWhere adds one. If condition is nil, OrWhere does
+// nothing.
+func (stmt *DeleteStmt) OrWhere(condition Expression) *DeleteStmt {
+	stmt.where = stmt.where.and("OR", condition)
+	return stmt
+}
+
+// Returning adds a RETURNING clause.
+func (stmt *DeleteStmt) Returning(exprs ...Expression) *DeleteStmt {
+	stmt.returning = exprs
+	return stmt
+}
+
+// Build builds stmt and its parameters. Build panics if stmt is invalid.
+func (stmt *DeleteStmt) Build() (string, []interface{}) {
+	return mustBuildStmt(stmt, Postgres)
+}
+
+// BuildFor builds stmt and its parameters for the dialect d. BuildFor panics
+// if stmt is invalid.
+func (stmt *DeleteStmt) BuildFor(d Dialect) (string, []interface{}) {
+	return mustBuildStmt(stmt, d)
+}
+
+// BuildErr builds stmt and its parameters. If stmt is invalid, BuildErr
+// returns a *BuildError.
+func (stmt *DeleteStmt) BuildErr() (string, []interface{}, error) {
+	return buildStmt(stmt, Postgres)
+}
+
+// BuildErrFor builds stmt and its parameters for the dialect d. If stmt is
+// invalid, BuildErrFor returns a *BuildError.
+func (stmt *DeleteStmt) BuildErrFor(d Dialect) (string, []interface{}, error) {
+	return buildStmt(stmt, d)
+}
+
+func (stmt *DeleteStmt) build(b *builder) {
+	if stmt.ctes != nil {
+		stmt.ctes.build(b)
+	}
+
+	b.write("DELETE FROM ")
+	stmt.table.build(b)
+
+	if stmt.using != nil {
+		b.clause()
+		stmt.using.build(b)
+	}
+
+	if stmt.where != nil {
+		b.clause()
+		stmt.where.build(b)
+	}
+
+	if stmt.returning != nil {
+		b.clause()
+		b.write("RETURNING ")
+		stmt.returning.build(b)
+	}
+}
+
+// A DeleteStmt is a DELETE statement.
+type DeleteStmt struct {
+	ctes      *CTEs
+	table     Expression
+	using     using
+	where     *where
+	returning selectexprs
+}
+
+type using []Expression
+
+func (u using) build(b *builder) {
+	b.write("USING ")
+	for i := range u {
+		if i > 0 {
+			b.write(", ")
+		}
+		buildFromItem(b, u[i])
+	}
+}
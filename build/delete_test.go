@@ -0,0 +1,56 @@
+package build
+
+import "testing"
+
+func TestDelete(t *testing.T) {
+	for _, tt := range []struct {
+		stmt *DeleteStmt
+		out  string
+		args []interface{}
+	}{{
+		stmt: DeleteFrom("films"),
+		out:  `DELETE FROM "films"`,
+	}, {
+		stmt: DeleteFrom("films").Where(Ident("kind").NotEqual(Bind("Musical"))),
+		out:  `DELETE FROM "films" WHERE "kind" != $1`,
+		args: []interface{}{"Musical"},
+	}, {
+		stmt: DeleteFrom("films").
+			Using(Ident("producers")).
+			Where(Ident("producer_id").Equal(Ident("producers.id")).
+				And(Ident("producers.name").Equal(Bind("foo")))),
+		out:  `DELETE FROM "films" USING "producers" WHERE "producer_id" = "producers"."id" AND "producers"."name" = $1`,
+		args: []interface{}{"foo"},
+	}, {
+		stmt: DeleteFrom("tasks").
+			Where(Ident("status").Equal(Bind("DONE"))).
+			Returning(Star),
+		out:  `DELETE FROM "tasks" WHERE "status" = $1 RETURNING *`,
+		args: []interface{}{"DONE"},
+	}, {
+		stmt: With("old", Select(Ident("id")).From(Ident("users")).Where(Ident("deleted").IsNotNull())).
+			DeleteFrom("sessions").
+			Using(Ident("old")).
+			Where(Ident("sessions.user_id").Equal(Ident("old.id"))),
+		out: `WITH "old" AS ( SELECT "id" FROM "users" WHERE "deleted" IS NOT NULL ) DELETE FROM "sessions" USING "old" WHERE "sessions"."user_id" = "old"."id"`,
+	}, {
+		stmt: DeleteFrom("tasks").
+			OrWhere(Ident("status").Equal(Bind("DONE"))).
+			OrWhere(Ident("status").Equal(Bind("CANCELED"))),
+		out:  `DELETE FROM "tasks" WHERE "status" = $1 OR "status" = $2`,
+		args: []interface{}{"DONE", "CANCELED"},
+	}} {
+		t.Run(tt.out, func(t *testing.T) {
+			out, args := tt.stmt.Build()
+			assertf(t, out == tt.out, "expected %q, got %q", tt.out, out)
+			assertf(t, len(args) == len(tt.args), "expected %d args, got %d", len(tt.args), len(args))
+			minlen := len(args)
+			if len(tt.args) < minlen {
+				minlen = len(tt.args)
+			}
+			for i := 0; i < minlen; i++ {
+				assertf(t, args[i] == tt.args[i], "expected %#v, got %#v", tt.args[i], args[i])
+			}
+		})
+	}
+}
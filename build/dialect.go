@@ -0,0 +1,175 @@
+package build
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// A Dialect controls how statements are rendered for a database.
+type Dialect interface {
+	// Placeholder returns the placeholder of the nth parameter, starting
+	// at 1.
+	Placeholder(n int) string
+	// QuoteIdent quotes a single identifier, without any dot.
+	QuoteIdent(name string) string
+	// QuoteString quotes a string literal.
+	QuoteString(s string) string
+	// LimitSyntax returns how LIMIT and OFFSET clauses are rendered.
+	LimitSyntax() LimitSyntax
+	// Supports reports whether the database supports the feature f.
+	Supports(f Feature) bool
+}
+
+// A LimitSyntax is the syntax of LIMIT and OFFSET clauses.
+type LimitSyntax int
+
+// LimitSyntax values.
+const (
+	// LimitOffset renders LIMIT count OFFSET start.
+	LimitOffset LimitSyntax = iota
+	// OffsetFetch renders OFFSET start ROWS FETCH NEXT count ROWS ONLY.
+	OffsetFetch
+)
+
+// A Feature is an optional feature of a database.
+type Feature int
+
+// Feature values.
+const (
+	// ParenthesizedCompounds is the support for parenthesized statements
+	// in UNION, INTERSECT and EXCEPT statements.
+	ParenthesizedCompounds Feature = iota
+	// IntersectExceptAll is the support for INTERSECT ALL and EXCEPT ALL.
+	IntersectExceptAll
+	// LockingClauses is the support for locking clauses, like FOR UPDATE.
+	LockingClauses
+	// Merge is the support for MERGE statements.
+	Merge
+	// MergeTerminator terminates MERGE statements with a semicolon, as
+	// required by SQL Server.
+	MergeTerminator
+	// ArrayLiterals is the support for ARRAY[...] literals.
+	ArrayLiterals
+	// BoolLiterals is the support for true and false literals. Booleans are
+	// written as 1 and 0 otherwise.
+	BoolLiterals
+	// TimeZoneLiterals is the support for time zone offsets in timestamp
+	// literals.
+	TimeZoneLiterals
+	// NaNLiterals is the support for 'NaN', 'Infinity' and '-Infinity'
+	// floating point literals.
+	NaNLiterals
+	// BinaryLiterals is the support for X'...' binary string literals.
+	BinaryLiterals
+	// HexLiterals is the support for 0x... binary string literals. Binary
+	// strings are written with the decode function if neither
+	// BinaryLiterals nor HexLiterals is supported.
+	HexLiterals
+)
+
+// Dialects.
+var (
+	Postgres  Dialect = postgres{}
+	MySQL     Dialect = mysql{}
+	SQLite    Dialect = sqlite{}
+	SQLServer Dialect = sqlserver{}
+)
+
+type postgres struct{}
+
+func (postgres) Placeholder(n int) string   { return "$" + strconv.Itoa(n) }
+func (postgres) QuoteIdent(s string) string { return quoteIdent(s) }
+func (postgres) LimitSyntax() LimitSyntax   { return LimitOffset }
+func (postgres) Supports(f Feature) bool {
+	switch f {
+	case ParenthesizedCompounds, IntersectExceptAll, LockingClauses, Merge,
+		ArrayLiterals, BoolLiterals, TimeZoneLiterals, NaNLiterals:
+		return true
+	}
+	return false
+}
+
+// QuoteString doubles single quotes. If s contains a backslash, it is doubled
+// and the literal is written as an escape string constant, so that it is read
+// back the same whatever the value of standard_conforming_strings.
+func (postgres) QuoteString(s string) string {
+	s = strings.ReplaceAll(s, "'", "''")
+	if strings.Contains(s, `\`) {
+		return `E'` + strings.ReplaceAll(s, `\`, `\\`) + `'`
+	}
+	return "'" + s + "'"
+}
+
+type mysql struct{}
+
+func (mysql) Placeholder(int) string { return "?" }
+func (mysql) QuoteIdent(s string) string {
+	return "`" + strings.ReplaceAll(s, "`", "``") + "`"
+}
+func (mysql) LimitSyntax() LimitSyntax { return LimitOffset }
+func (mysql) Supports(f Feature) bool {
+	switch f {
+	case ParenthesizedCompounds, IntersectExceptAll, LockingClauses,
+		BoolLiterals, BinaryLiterals, HexLiterals:
+		return true
+	}
+	return false
+}
+
+// QuoteString doubles single quotes and backslashes, as backslash is an
+// escape character unless NO_BACKSLASH_ESCAPES is set.
+func (mysql) QuoteString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+type sqlite struct{}
+
+func (sqlite) Placeholder(int) string      { return "?" }
+func (sqlite) QuoteIdent(s string) string  { return quoteIdent(s) }
+func (sqlite) QuoteString(s string) string { return quoteString(s) }
+func (sqlite) LimitSyntax() LimitSyntax    { return LimitOffset }
+func (sqlite) Supports(f Feature) bool {
+	switch f {
+	case BoolLiterals, TimeZoneLiterals, BinaryLiterals:
+		return true
+	}
+	return false
+}
+
+type sqlserver struct{}
+
+func (sqlserver) Placeholder(n int) string { return "@p" + strconv.Itoa(n) }
+func (sqlserver) QuoteIdent(s string) string {
+	return "[" + strings.ReplaceAll(s, "]", "]]") + "]"
+}
+func (sqlserver) LimitSyntax() LimitSyntax { return OffsetFetch }
+func (sqlserver) Supports(f Feature) bool {
+	switch f {
+	case ParenthesizedCompounds, Merge, MergeTerminator, HexLiterals:
+		return true
+	}
+	return false
+}
+
+// QuoteString doubles single quotes. Strings with non-ASCII characters are
+// written as Unicode literals.
+func (sqlserver) QuoteString(s string) string {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return "N" + quoteString(s)
+		}
+	}
+	return quoteString(s)
+}
+
+// quoteIdent doubles double quotes, as specified by the SQL standard.
+func quoteIdent(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+}
+
+// quoteString doubles single quotes, as specified by the SQL standard.
+func quoteString(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
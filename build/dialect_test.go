@@ -0,0 +1,157 @@
+package build
+
+import "testing"
+
+func TestDialect(t *testing.T) {
+	stmt := Select(Columns("id", "name")...).
+		From(Ident("public.users")).
+		Where(Ident("id").In(Bind([]int64{1, 2})).And(Ident("name").NotEqual(Bind("foo")))).
+		OrderBy(Ident("id")).
+		Limit(Bind(10)).
+		Offset(Bind(20))
+	for _, tt := range []struct {
+		dialect Dialect
+		out     string
+	}{{
+		dialect: Postgres,
+		out:     `SELECT "id", "name" FROM "public"."users" WHERE "id" IN ($1, $2) AND "name" != $3 ORDER BY "id" LIMIT $4 OFFSET $5`,
+	}, {
+		dialect: MySQL,
+		out:     "SELECT `id`, `name` FROM `public`.`users` WHERE `id` IN (?, ?) AND `name` != ? ORDER BY `id` LIMIT ? OFFSET ?",
+	}, {
+		dialect: SQLite,
+		out:     `SELECT "id", "name" FROM "public"."users" WHERE "id" IN (?, ?) AND "name" != ? ORDER BY "id" LIMIT ? OFFSET ?`,
+	}, {
+		dialect: SQLServer,
+		out:     `SELECT [id], [name] FROM [public].[users] WHERE [id] IN (@p1, @p2) AND [name] != @p3 ORDER BY [id] OFFSET @p4 ROWS FETCH NEXT @p5 ROWS ONLY`,
+	}} {
+		t.Run(tt.out, func(t *testing.T) {
+			out, args := stmt.BuildFor(tt.dialect)
+			assertf(t, out == tt.out, "expected %q, got %q", tt.out, out)
+			assertf(t, len(args) == 5, "expected 5 args, got %d", len(args))
+		})
+	}
+}
+
+// noFeatures is a dialect which supports no optional feature.
+type noFeatures struct{ Dialect }
+
+func (noFeatures) Supports(Feature) bool { return false }
+
+func TestDialectFeatures(t *testing.T) {
+	d := noFeatures{Postgres}
+	out, _ := Union(Select(Int(1)), Select(Int(2)).Limit(Bind(1))).BuildFor(d)
+	expected := `SELECT 1 UNION SELECT * FROM (SELECT 2 LIMIT $1)`
+	assertf(t, out == expected, "expected %q, got %q", expected, out)
+
+	for _, d := range []Dialect{d, SQLServer} {
+		_, _, err := IntersectAll(Select(Int(1)), Select(Int(2))).BuildErrFor(d)
+		assertf(t, err != nil, "expected an error")
+	}
+}
+
+func TestOffsetFetchUnordered(t *testing.T) {
+	out, _ := Select(Star).From(Ident("t")).Limit(Int(1)).BuildFor(SQLServer)
+	expected := `SELECT * FROM [t] ORDER BY (SELECT NULL) OFFSET 0 ROWS FETCH NEXT 1 ROWS ONLY`
+	assertf(t, out == expected, "expected %q, got %q", expected, out)
+}
+
+func TestQuote(t *testing.T) {
+	for _, tt := range []struct {
+		dialect Dialect
+		expr    Expression
+		out     string
+	}{
+		{dialect: Postgres, expr: String("it's"), out: `SELECT 'it''s'`},
+		{dialect: Postgres, expr: String(`C:\dir`), out: `SELECT E'C:\\dir'`},
+		{dialect: Postgres, expr: String("a\nb"), out: "SELECT 'a\nb'"},
+		{dialect: Postgres, expr: Ident(`my "table"`), out: `SELECT "my ""table"""`},
+		{dialect: MySQL, expr: String(`it's C:\dir`), out: `SELECT 'it''s C:\\dir'`},
+		{dialect: MySQL, expr: Ident("my `table`"), out: "SELECT `my ``table```"},
+		{dialect: SQLite, expr: String(`it's C:\dir`), out: `SELECT 'it''s C:\dir'`},
+		{dialect: SQLServer, expr: String("Salaün's"), out: `SELECT N'Salaün''s'`},
+		{dialect: SQLServer, expr: Ident("my [table]"), out: `SELECT [my [table]]]`},
+	} {
+		t.Run(tt.out, func(t *testing.T) {
+			out, _ := Select(tt.expr).BuildFor(tt.dialect)
+			assertf(t, out == tt.out, "expected %q, got %q", tt.out, out)
+		})
+	}
+}
+
+func TestQuoteNUL(t *testing.T) {
+	for _, expr := range []Expression{String("a\x00b"), Ident("a\x00b")} {
+		_, _, err := Select(expr).BuildErr()
+		assertf(t, err != nil, "expected an error")
+	}
+}
+
+func FuzzQuote(f *testing.F) {
+	for _, s := range []string{"", "foo", "it's", `"quoted"`, `C:\dir`, "a\nb", "`tick`", "[bracket]", "Salaün", `\'`, `'\`} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		for _, tt := range []struct {
+			name   string
+			quoted string
+			open   string
+			close  byte
+			escape bool
+		}{
+			{name: "postgres ident", quoted: Postgres.QuoteIdent(s), open: `"`, close: '"'},
+			{name: "postgres string", quoted: Postgres.QuoteString(s), open: "'", close: '\''},
+			{name: "mysql ident", quoted: MySQL.QuoteIdent(s), open: "`", close: '`'},
+			{name: "mysql string", quoted: MySQL.QuoteString(s), open: "'", close: '\'', escape: true},
+			{name: "sqlite ident", quoted: SQLite.QuoteIdent(s), open: `"`, close: '"'},
+			{name: "sqlite string", quoted: SQLite.QuoteString(s), open: "'", close: '\''},
+			{name: "sqlserver ident", quoted: SQLServer.QuoteIdent(s), open: "[", close: ']'},
+			{name: "sqlserver string", quoted: SQLServer.QuoteString(s), open: "'", close: '\''},
+		} {
+			quoted, escape := tt.quoted, tt.escape
+			switch {
+			case tt.name == "postgres string" && len(quoted) > 0 && quoted[0] == 'E':
+				quoted, escape = quoted[1:], true
+			case tt.name == "sqlserver string" && len(quoted) > 0 && quoted[0] == 'N':
+				quoted = quoted[1:]
+			}
+			got, ok := unquote(quoted, tt.open, tt.close, escape)
+			if !ok {
+				t.Fatalf("%s: %q is not a single quoted token", tt.name, tt.quoted)
+			}
+			if got != s {
+				t.Fatalf("%s: %q unquotes to %q, expected %q", tt.name, tt.quoted, got, s)
+			}
+		}
+	})
+}
+
+// unquote reads back a quoted token the way a SQL lexer does: the closing
+// character is escaped by doubling it and, if escape is true, any character
+// is escaped by a backslash. unquote reports false if the token ends before
+// the end of quoted.
+func unquote(quoted, open string, close byte, escape bool) (string, bool) {
+	if len(quoted) < len(open)+1 || quoted[:len(open)] != open {
+		return "", false
+	}
+	var out []byte
+	for i := len(open); i < len(quoted); i++ {
+		switch c := quoted[i]; {
+		case escape && c == '\\':
+			i++
+			if i == len(quoted) {
+				return "", false
+			}
+			out = append(out, quoted[i])
+		case c == close:
+			if i+1 < len(quoted) && quoted[i+1] == close {
+				out = append(out, close)
+				i++
+				continue
+			}
+			return string(out), i == len(quoted)-1
+		default:
+			out = append(out, c)
+		}
+	}
+	return "", false
+}
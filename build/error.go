@@ -0,0 +1,17 @@
+package build
+
+import "strings"
+
+// A BuildError is returned when building an invalid statement. It holds every
+// error found in the statement.
+type BuildError struct {
+	Errs []error
+}
+
+func (e *BuildError) Error() string {
+	msgs := make([]string, 0, len(e.Errs))
+	for i := range e.Errs {
+		msgs = append(msgs, e.Errs[i].Error())
+	}
+	return "build: " + strings.Join(msgs, "; ")
+}
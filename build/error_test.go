@@ -0,0 +1,121 @@
+package build
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBuildErr(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		stmt interface {
+			BuildErr() (string, []interface{}, error)
+		}
+		errs []string
+	}{{
+		name: "unsupported bind type",
+		stmt: Select(Star).From(Ident("foo")).Where(Ident("id").Equal(Bind(map[string]int{"a": 1}))),
+		errs: []string{`don't know how to bind value map[string]int{"a":1} (map[string]int)`},
+	}, {
+		name: "unknown direction and nulls",
+		stmt: Select(Star).From(Ident("foo")).OrderBy(Order(Ident("id"), Direction(42)).Nulls(Nulls(42))),
+		errs: []string{"unknown direction 42", "unknown nulls 42"},
+	}, {
+		name: "nil where",
+		stmt: Select(Star).From(Ident("foo")).Where(nil),
+		errs: []string{"WHERE clause has a nil condition"},
+	}, {
+		name: "empty set",
+		stmt: Update("foo").Where(Ident("id").Equal(Bind(1))),
+		errs: []string{"UPDATE statement has no assignments"},
+	}, {
+		name: "missing values",
+		stmt: InsertInto("foo", "bar"),
+		errs: []string{"INSERT statement has no values"},
+	}, {
+		name: "unknown conflict action",
+		stmt: InsertInto("foo").DefaultValues().OnConflict(ConflictAction{do: 42}),
+		errs: []string{"unknown conflict action 42"},
+	}, {
+		name: "empty do update set",
+		stmt: InsertInto("foo").DefaultValues().OnConflict(DoUpdateSet()),
+		errs: []string{"DO UPDATE SET conflict action has no assignments"},
+	}, {
+		name: "do nothing where",
+		stmt: InsertInto("foo").DefaultValues().OnConflict(DoNothing.Where(Ident("bar").IsNull())),
+		errs: []string{"DO NOTHING conflict action has a WHERE clause"},
+	}, {
+		name: "incomplete merge",
+		stmt: MergeInto("foo"),
+		errs: []string{"MERGE statement has no data source", "MERGE statement has no join condition", "MERGE statement has no WHEN clauses"},
+	}, {
+		name: "nil merge join condition",
+		stmt: MergeInto("foo").Using(Ident("bar")).On(And()).When(WhenMatched().ThenDelete()),
+		errs: []string{"MERGE statement has no join condition"},
+	}, {
+		name: "invalid merge actions",
+		stmt: MergeInto("foo").Using(Ident("bar")).On(Bool(true)).When(
+			WhenNotMatched().ThenUpdate(),
+			WhenNotMatched().ThenDelete(),
+			WhenMatched().ThenInsert(nil, nil),
+			WhenMatched(),
+		),
+		errs: []string{
+			"WHEN NOT MATCHED clause cannot UPDATE",
+			"MERGE UPDATE action has no assignments",
+			"WHEN NOT MATCHED clause cannot DELETE",
+			"WHEN MATCHED clause cannot INSERT",
+			"MERGE INSERT action has no values",
+			"MERGE WHEN clause has no action",
+		},
+	}, {
+		name: "nowait and skip locked",
+		stmt: Select(Star).From(Ident("foo")).For(ForUpdate().NoWait().SkipLocked()),
+		errs: []string{"locking clause has both NOWAIT and SKIP LOCKED"},
+	}, {
+		name: "distinct and distinct on",
+		stmt: Select(Star).Distinct().DistinctOn(Ident("a")).From(Ident("foo")),
+		errs: []string{"SELECT statement has both DISTINCT and DISTINCT ON"},
+	}, {
+		name: "empty values table",
+		stmt: Select(Star).From(ValuesTable().As("v")),
+		errs: []string{"VALUES list has no rows"},
+	}, {
+		name: "subquery without alias",
+		stmt: Select(Star).From(FromItem(Ident("a")).Join(Select(Int(1))).On(Bool(true)), FromExpr(Select(Int(2)))),
+		errs: []string{"subquery in FROM clause has no alias", "subquery in FROM clause has no alias"},
+	}, {
+		name: "join without condition",
+		stmt: Select(Star).From(FromItem(Ident("a")).Join(Ident("b")).On(And())),
+		errs: []string{"JOIN has no ON or USING clause"},
+	}, {
+		name: "join with on and using",
+		stmt: Select(Star).From(FromItem(Ident("a")).Join(Ident("b")).On(Bool(true)).(JoinExpr).Using("id")),
+		errs: []string{"JOIN has both ON and USING clauses"},
+	}, {
+		name: "nil delete where",
+		stmt: DeleteFrom("foo").Where(nil),
+		errs: []string{"WHERE clause has a nil condition"},
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			out, args, err := tt.stmt.BuildErr()
+			assertf(t, out == "" && args == nil, "expected no output, got %q and %v", out, args)
+			var builderr *BuildError
+			if !errors.As(err, &builderr) {
+				t.Fatalf("expected a *BuildError, got %#v", err)
+			}
+			assertf(t, len(builderr.Errs) == len(tt.errs), "expected %d errors, got %d", len(tt.errs), len(builderr.Errs))
+			for i := 0; i < len(tt.errs) && i < len(builderr.Errs); i++ {
+				assertf(t, builderr.Errs[i].Error() == tt.errs[i], "expected %q, got %q", tt.errs[i], builderr.Errs[i])
+			}
+		})
+	}
+}
+
+func TestBuildPanics(t *testing.T) {
+	defer func() {
+		_, ok := recover().(*BuildError)
+		assertf(t, ok, "expected a *BuildError panic")
+	}()
+	Select(Bind(struct{}{})).Build()
+}
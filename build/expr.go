@@ -2,13 +2,15 @@ package build
 
 import (
 	"fmt"
+	"reflect"
 	"strconv"
 	"strings"
 )
 
 type Expression interface{ build(*builder) }
 
-// Bind binds a value.
+// Bind binds a value. Lists are bound as lists of parameters. BuildErr
+// reports an error for empty lists, which Build writes as ().
 func Bind(value interface{}) *InfixExpr {
 	return &InfixExpr{left: &bind{value: value}}
 }
@@ -16,7 +18,65 @@ func Bind(value interface{}) *InfixExpr {
 type bind struct{ value interface{} }
 
 func (bind *bind) build(b *builder) {
+	slot := bindSlot{start: len(b.params), list: isList(bind.value)}
 	b.bind(bind.value)
+	slot.n = len(b.params) - slot.start
+	slot.nested = slot.list && slot.n != reflect.ValueOf(bind.value).Len()
+	b.binds = append(b.binds, slot)
+}
+
+// BindArray binds a slice as a single array parameter, instead of a list of
+// parameters. The driver must support binding value, for example if value
+// implements driver.Valuer.
+func BindArray(value interface{}) *InfixExpr {
+	return &InfixExpr{left: &bindArray{value: value}}
+}
+
+type bindArray struct{ value interface{} }
+
+func (bind *bindArray) build(b *builder) {
+	b.binds = append(b.binds, bindSlot{start: len(b.params), n: 1, array: true})
+	b.param(bind.value)
+}
+
+// Any returns an ANY expression, to compare a value with the elements of an
+// array or the rows of a subquery.
+func Any(array Expression) *InfixExpr {
+	return &InfixExpr{left: &keywordExpr{keyword: "ANY", expr: array}}
+}
+
+// All returns an ALL expression, to compare a value with the elements of an
+// array or the rows of a subquery.
+func All(array Expression) *InfixExpr {
+	return &InfixExpr{left: &keywordExpr{keyword: "ALL", expr: array}}
+}
+
+// Exists returns an EXISTS expression.
+func Exists(subquery Expression) *InfixExpr {
+	return &InfixExpr{left: &keywordExpr{keyword: "EXISTS", expr: subquery}}
+}
+
+// NotExists returns a NOT EXISTS expression.
+func NotExists(subquery Expression) *InfixExpr {
+	return Not(Exists(subquery))
+}
+
+// A keywordExpr is a keyword followed by a parenthesized expression, which is
+// not parenthesized again if it is a subquery.
+type keywordExpr struct {
+	keyword string
+	expr    Expression
+}
+
+func (e *keywordExpr) build(b *builder) {
+	b.write(e.keyword)
+	if isQuery(e.expr) {
+		b.subquery(e.expr)
+		return
+	}
+	b.write("(")
+	e.expr.build(b)
+	b.write(")")
 }
 
 // An InfixExpr is an infix expression.
@@ -27,14 +87,17 @@ type InfixExpr struct {
 }
 
 func (i *InfixExpr) build(b *builder) {
-	if i.left != nil {
-		i.left.build(b)
-	}
-
 	if i.op == "" {
+		if i.left != nil {
+			buildOperand(b, i.left, false)
+		}
 		return
 	}
+
+	p := opPrecedence(i.op)
 	if i.left != nil {
+		lp := precedence(i.left)
+		buildOperand(b, i.left, lp < p || lp == p && !isLeftAssociative(p))
 		b.write(" ")
 	}
 	b.write(i.op)
@@ -43,7 +106,8 @@ func (i *InfixExpr) build(b *builder) {
 		return
 	}
 	b.write(" ")
-	i.right.build(b)
+	rp := precedence(i.right)
+	buildOperand(b, i.right, rp < p || rp == p && !isAssociative(i.op, i.right))
 }
 
 // And invokes the AND operator.
@@ -86,6 +150,98 @@ func (i *InfixExpr) GreaterThanOrEqualTo(right Expression) *InfixExpr {
 	return i.Op(">=", right)
 }
 
+// LessThanOrEqualTo invokes the <= operator.
+func (i *InfixExpr) LessThanOrEqualTo(right Expression) *InfixExpr {
+	return i.Op("<=", right)
+}
+
+// NotIn invokes the NOT IN operator.
+func (i *InfixExpr) NotIn(right Expression) *InfixExpr {
+	return i.Op("NOT IN", right)
+}
+
+// Like invokes the LIKE operator.
+func (i *InfixExpr) Like(pattern Expression) *InfixExpr {
+	return i.Op("LIKE", pattern)
+}
+
+// NotLike invokes the NOT LIKE operator.
+func (i *InfixExpr) NotLike(pattern Expression) *InfixExpr {
+	return i.Op("NOT LIKE", pattern)
+}
+
+// ILike invokes the ILIKE operator.
+func (i *InfixExpr) ILike(pattern Expression) *InfixExpr {
+	return i.Op("ILIKE", pattern)
+}
+
+// NotILike invokes the NOT ILIKE operator.
+func (i *InfixExpr) NotILike(pattern Expression) *InfixExpr {
+	return i.Op("NOT ILIKE", pattern)
+}
+
+// IsDistinctFrom invokes the IS DISTINCT FROM operator.
+func (i *InfixExpr) IsDistinctFrom(right Expression) *InfixExpr {
+	return i.Op("IS DISTINCT FROM", right)
+}
+
+// IsNotDistinctFrom invokes the IS NOT DISTINCT FROM operator.
+func (i *InfixExpr) IsNotDistinctFrom(right Expression) *InfixExpr {
+	return i.Op("IS NOT DISTINCT FROM", right)
+}
+
+// Between adds the BETWEEN predicate.
+func (i *InfixExpr) Between(low, high Expression) *InfixExpr {
+	return &InfixExpr{left: &betweenExpr{expr: i.left, low: low, high: high}}
+}
+
+// NotBetween adds the NOT BETWEEN predicate.
+func (i *InfixExpr) NotBetween(low, high Expression) *InfixExpr {
+	return &InfixExpr{left: &betweenExpr{expr: i.left, not: true, low: low, high: high}}
+}
+
+type betweenExpr struct {
+	expr      Expression
+	not       bool
+	low, high Expression
+}
+
+func (e *betweenExpr) build(b *builder) {
+	buildOperand(b, e.expr, precedence(e.expr) <= precLike)
+	if e.not {
+		b.write(" NOT")
+	}
+	b.write(" BETWEEN ")
+	buildOperand(b, e.low, precedence(e.low) <= precLike)
+	b.write(" AND ")
+	buildOperand(b, e.high, precedence(e.high) <= precLike)
+}
+
+// Add invokes the + operator.
+func (i *InfixExpr) Add(right Expression) *InfixExpr {
+	return i.Op("+", right)
+}
+
+// Subtract invokes the - operator.
+func (i *InfixExpr) Subtract(right Expression) *InfixExpr {
+	return i.Op("-", right)
+}
+
+// Multiply invokes the * operator.
+func (i *InfixExpr) Multiply(right Expression) *InfixExpr {
+	return i.Op("*", right)
+}
+
+// Divide invokes the / operator.
+func (i *InfixExpr) Divide(right Expression) *InfixExpr {
+	return i.Op("/", right)
+}
+
+// Concat invokes the || operator.
+func (i *InfixExpr) Concat(right Expression) *InfixExpr {
+	return i.Op("||", right)
+}
+
 // Op invokes an operator.
 func (i *InfixExpr) Op(op string, right Expression) *InfixExpr {
 	return &InfixExpr{left: &InfixExpr{left: i.left, op: op, right: right}}
@@ -117,7 +273,7 @@ func (e callExpr) build(b *builder) {
 		if i > 0 {
 			b.write(", ")
 		}
-		arg.build(b)
+		buildOperand(b, arg, false)
 	}
 	b.write(")")
 }
@@ -132,7 +288,7 @@ func (i identifier) build(b *builder) {
 	quoted := make([]string, 0, len(split))
 	for i := range split {
 		quoted = append(quoted,
-			strconv.Quote(split[i]), // TODO: quote only if the identifier must be quoted?
+			b.quoteIdent(split[i]), // TODO: quote only if the identifier must be quoted?
 		)
 	}
 	b.write(strings.Join(quoted, "."))
@@ -167,7 +323,7 @@ func String(s string) Expression { return stringExpr(s) }
 type stringExpr string
 
 func (s stringExpr) build(b *builder) {
-	b.write(fmt.Sprintf("'%s'", s))
+	b.write(b.quoteString(string(s)))
 }
 
 var Star Expression = star{}
@@ -178,7 +334,8 @@ func (star) build(b *builder) {
 	b.write("*")
 }
 
-// Raw returns a raw expression.
+// Raw returns a raw expression. Unless s is a single term, like a name or a
+// function call, s is parenthesized when it is an operand.
 func Raw(s string) *InfixExpr { return &InfixExpr{left: raw(s)} }
 
 type raw string
@@ -187,9 +344,101 @@ func (r raw) build(b *builder) {
 	b.write(string(r))
 }
 
+// RawArgs returns a raw expression with parameters. Each ? marker in s is
+// replaced with the placeholder of the next value of args, bound like Bind.
+// Use ?? for a literal question mark. Markers in quoted strings and
+// identifiers are left unchanged. Like with Raw, s is parenthesized when it
+// is an operand, unless it is a single term.
+func RawArgs(s string, args ...interface{}) *InfixExpr {
+	r := &rawArgs{s: s, args: make([]Expression, len(args))}
+	for i := range args {
+		r.args[i] = &bind{value: args[i]}
+	}
+	return &InfixExpr{left: r}
+}
+
+type rawArgs struct {
+	s    string
+	args []Expression
+}
+
+func (r *rawArgs) build(b *builder) {
+	var (
+		n     int
+		quote byte
+		start int
+	)
+	for i := 0; i < len(r.s); i++ {
+		c := r.s[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '?':
+			b.write(r.s[start:i])
+			if i+1 < len(r.s) && r.s[i+1] == '?' {
+				b.write("?")
+				i++
+			} else {
+				if n < len(r.args) {
+					r.args[n].build(b)
+				}
+				n++
+			}
+			start = i + 1
+		}
+	}
+	b.write(r.s[start:])
+	if n != len(r.args) {
+		b.errorf("raw expression %q has %d markers, got %d args", r.s, n, len(r.args))
+	}
+}
+
+// And joins the non-nil exprs with the AND operator. And returns nil if all
+// exprs are nil, and the expression itself if only one is not nil.
+func And(exprs ...Expression) *InfixExpr {
+	return join("AND", exprs)
+}
+
+// Or joins the non-nil exprs with the OR operator. Or returns nil if all
+// exprs are nil, and the expression itself if only one is not nil.
+func Or(exprs ...Expression) *InfixExpr {
+	return join("OR", exprs)
+}
+
+func join(op string, exprs []Expression) *InfixExpr {
+	var joined *InfixExpr
+	for _, expr := range exprs {
+		if isNil(expr) {
+			continue
+		}
+		if joined == nil {
+			if i, ok := expr.(*InfixExpr); ok {
+				joined = i
+			} else {
+				joined = &InfixExpr{left: expr}
+			}
+			continue
+		}
+		joined = joined.Op(op, expr)
+	}
+	return joined
+}
+
+// isNil reports whether expr is nil, or a nil *InfixExpr.
+func isNil(expr Expression) bool {
+	if i, ok := expr.(*InfixExpr); ok {
+		return i == nil
+	}
+	return expr == nil
+}
+
 // Not adds the NOT operator to an expression.
 func Not(expr Expression) *InfixExpr {
-	return &InfixExpr{left: nil, op: "NOT", right: expr}
+	return &InfixExpr{left: &InfixExpr{op: "NOT", right: expr}}
 }
 
 func ParenExpr(expr Expression) *InfixExpr {
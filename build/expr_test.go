@@ -0,0 +1,242 @@
+package build
+
+import "testing"
+
+func TestOperators(t *testing.T) {
+	for _, tt := range []struct {
+		expr Expression
+		out  string
+		args []interface{}
+	}{{
+		expr: Ident("a").LessThanOrEqualTo(Bind(1)),
+		out:  `SELECT "a" <= $1`,
+		args: []interface{}{1},
+	}, {
+		expr: Ident("a").NotIn(Bind([]int{1, 2})),
+		out:  `SELECT "a" NOT IN ($1, $2)`,
+		args: []interface{}{1, 2},
+	}, {
+		expr: Ident("a").Like(Bind("foo%")).And(Ident("b").NotLike(Bind("bar%"))),
+		out:  `SELECT "a" LIKE $1 AND "b" NOT LIKE $2`,
+		args: []interface{}{"foo%", "bar%"},
+	}, {
+		expr: Ident("a").ILike(Bind("foo%")).Or(Ident("b").NotILike(Bind("bar%"))),
+		out:  `SELECT "a" ILIKE $1 OR "b" NOT ILIKE $2`,
+		args: []interface{}{"foo%", "bar%"},
+	}, {
+		expr: Ident("a").Between(Bind(1), Bind(10)).And(Ident("b").NotBetween(Int(1), Int(10))),
+		out:  `SELECT "a" BETWEEN $1 AND $2 AND "b" NOT BETWEEN 1 AND 10`,
+		args: []interface{}{1, 10},
+	}, {
+		expr: Ident("a").IsDistinctFrom(Bind(1)).And(Ident("b").IsNotDistinctFrom(Ident("c"))),
+		out:  `SELECT "a" IS DISTINCT FROM $1 AND "b" IS NOT DISTINCT FROM "c"`,
+		args: []interface{}{1},
+	}, {
+		expr: Ident("a").GreaterThan(All(BindArray(1))).And(Ident("b").Equal(Any(Ident("c")))),
+		out:  `SELECT "a" > ALL($1) AND "b" = ANY("c")`,
+		args: []interface{}{1},
+	}, {
+		expr: Ident("a").Add(Int(1)).Subtract(Int(2)),
+		out:  `SELECT "a" + 1 - 2`,
+	}, {
+		expr: Ident("a").Multiply(Int(3)).Divide(Int(4)),
+		out:  `SELECT "a" * 3 / 4`,
+	}, {
+		expr: Ident("first_name").Concat(String(" ")).Concat(Ident("last_name")),
+		out:  `SELECT "first_name" || ' ' || "last_name"`,
+	}, {
+		expr: Exists(Select(Int(1)).From(Ident("t"))).And(NotExists(Select(Int(2)).From(Ident("u")))),
+		out:  `SELECT EXISTS(SELECT 1 FROM "t") AND NOT EXISTS(SELECT 2 FROM "u")`,
+	}, {
+		expr: Ident("a").Or(Ident("b")).And(Ident("c")),
+		out:  `SELECT ("a" OR "b") AND "c"`,
+	}, {
+		expr: Ident("a").And(Ident("b").Or(Ident("c"))),
+		out:  `SELECT "a" AND ("b" OR "c")`,
+	}, {
+		expr: Ident("a").Or(Ident("b").And(Ident("c"))),
+		out:  `SELECT "a" OR "b" AND "c"`,
+	}, {
+		expr: Not(Ident("a").Or(Ident("b"))).And(Ident("c")),
+		out:  `SELECT NOT ("a" OR "b") AND "c"`,
+	}, {
+		expr: Ident("a").Between(Ident("b").Or(Ident("c")), Ident("d")),
+		out:  `SELECT "a" BETWEEN ("b" OR "c") AND "d"`,
+	}} {
+		t.Run(tt.out, func(t *testing.T) {
+			out, args := Select(tt.expr).Build()
+			assertf(t, out == tt.out, "expected %q, got %q", tt.out, out)
+			assertf(t, len(args) == len(tt.args), "expected %d args, got %d", len(tt.args), len(args))
+			minlen := len(args)
+			if len(tt.args) < minlen {
+				minlen = len(tt.args)
+			}
+			for i := 0; i < minlen; i++ {
+				assertf(t, args[i] == tt.args[i], "expected %#v, got %#v", tt.args[i], args[i])
+			}
+		})
+	}
+}
+
+func TestPrecedence(t *testing.T) {
+	for _, tt := range []struct {
+		expr Expression
+		out  string
+	}{{
+		expr: Ident("a").Add(Int(1)).Multiply(Int(2)),
+		out:  `SELECT ("a" + 1) * 2`,
+	}, {
+		expr: Ident("a").Add(Ident("b").Multiply(Int(2))),
+		out:  `SELECT "a" + "b" * 2`,
+	}, {
+		expr: Ident("a").Subtract(Ident("b").Subtract(Ident("c"))),
+		out:  `SELECT "a" - ("b" - "c")`,
+	}, {
+		expr: Ident("a").Subtract(Ident("b")).Subtract(Ident("c")),
+		out:  `SELECT "a" - "b" - "c"`,
+	}, {
+		expr: Ident("a").Concat(Ident("b").Concat(Ident("c"))),
+		out:  `SELECT "a" || "b" || "c"`,
+	}, {
+		expr: Ident("a").And(Ident("b").And(Ident("c"))),
+		out:  `SELECT "a" AND "b" AND "c"`,
+	}, {
+		expr: Ident("a").Equal(Ident("b")).Equal(Bool(true)),
+		out:  `SELECT ("a" = "b") = true`,
+	}, {
+		expr: Ident("a").Equal(Int(1)).IsNull(),
+		out:  `SELECT "a" = 1 IS NULL`,
+	}, {
+		expr: Ident("a").IsNull().Equal(Bool(false)),
+		out:  `SELECT ("a" IS NULL) = false`,
+	}, {
+		expr: Not(Ident("a").Equal(Int(1))),
+		out:  `SELECT NOT "a" = 1`,
+	}, {
+		expr: Not(Ident("a").And(Ident("b"))),
+		out:  `SELECT NOT ("a" AND "b")`,
+	}, {
+		expr: Ident("a").Op("->", String("b")).Op("->>", String("c")),
+		out:  `SELECT "a" -> 'b' ->> 'c'`,
+	}, {
+		expr: Ident("a").Op("->>", String("b")).Equal(String("c")),
+		out:  `SELECT "a" ->> 'b' = 'c'`,
+	}, {
+		expr: Ident("a").Add(Int(1)).Op("::", Raw("text")),
+		out:  `SELECT ("a" + 1) :: text`,
+	}, {
+		expr: Ident("a").Op("COLLATE", Raw(`"C"`)).Op("::", Raw("text")),
+		out:  `SELECT ("a" COLLATE "C") :: text`,
+	}, {
+		expr: Ident("t").Op("AT TIME ZONE", String("UTC")).Multiply(Int(2)),
+		out:  `SELECT "t" AT TIME ZONE 'UTC' * 2`,
+	}, {
+		expr: Ident("a").Equal(Raw("now()")).And(Raw("b OR c")),
+		out:  `SELECT "a" = now() AND (b OR c)`,
+	}, {
+		expr: Raw("a OR b").Or(Raw("c.*")),
+		out:  `SELECT (a OR b) OR c.*`,
+	}, {
+		expr: Ident("id").In(Select(Ident("id")).From(Ident("t"))),
+		out:  `SELECT "id" IN (SELECT "id" FROM "t")`,
+	}, {
+		expr: Ident("n").Equal(Select(CallExpr("max", Ident("n"))).From(Ident("t"))),
+		out:  `SELECT "n" = (SELECT max("n") FROM "t")`,
+	}, {
+		expr: Ident("n").Equal(Any(Select(Ident("n")).From(Ident("t")))),
+		out:  `SELECT "n" = ANY(SELECT "n" FROM "t")`,
+	}, {
+		expr: CallExpr("coalesce", Select(CallExpr("max", Ident("n"))).From(Ident("t")), Int(0)),
+		out:  `SELECT coalesce((SELECT max("n") FROM "t"), 0)`,
+	}, {
+		expr: Select(Int(1)),
+		out:  `SELECT (SELECT 1)`,
+	}} {
+		t.Run(tt.out, func(t *testing.T) {
+			out, _ := Select(tt.expr).Build()
+			assertf(t, out == tt.out, "expected %q, got %q", tt.out, out)
+		})
+	}
+}
+
+func TestAndOr(t *testing.T) {
+	var nilexpr *InfixExpr
+	assertf(t, And() == nil, "expected nil")
+	assertf(t, Or(nil, nilexpr) == nil, "expected nil")
+
+	for _, tt := range []struct {
+		expr Expression
+		out  string
+	}{{
+		expr: And(nil, Ident("a"), nilexpr),
+		out:  `SELECT "a"`,
+	}, {
+		expr: And(Ident("a"), nil, Ident("b"), Ident("c")),
+		out:  `SELECT "a" AND "b" AND "c"`,
+	}, {
+		expr: Or(Ident("a"), And(Ident("b"), Ident("c")), Ident("d")),
+		out:  `SELECT "a" OR "b" AND "c" OR "d"`,
+	}, {
+		expr: And(Ident("a"), Or(Ident("b"), Ident("c"))),
+		out:  `SELECT "a" AND ("b" OR "c")`,
+	}} {
+		t.Run(tt.out, func(t *testing.T) {
+			out, _ := Select(tt.expr).Build()
+			assertf(t, out == tt.out, "expected %q, got %q", tt.out, out)
+		})
+	}
+}
+
+func TestRawArgs(t *testing.T) {
+	for _, tt := range []struct {
+		dialect Dialect
+		stmt    *SelectStmt
+		out     string
+		args    []interface{}
+	}{{
+		dialect: Postgres,
+		stmt: Select(Star).From(Ident("users")).
+			Where(Ident("active").Equal(Bind(true)).And(RawArgs("lower(email) = ? AND org = ?", "foo@example.com", 2))),
+		out:  `SELECT * FROM "users" WHERE "active" = $1 AND (lower(email) = $2 AND org = $3)`,
+		args: []interface{}{true, "foo@example.com", 2},
+	}, {
+		dialect: Postgres,
+		stmt: Select(Star).From(Ident("users")).
+			Where(Ident("active").Equal(Bind(true)).And(RawArgs("a = ? OR b = ?", 1, 2))),
+		out:  `SELECT * FROM "users" WHERE "active" = $1 AND (a = $2 OR b = $3)`,
+		args: []interface{}{true, 1, 2},
+	}, {
+		dialect: Postgres,
+		stmt:    Select(Ident("n").Multiply(RawArgs("coalesce(?, 1)", 2)).Add(RawArgs("?", 3))),
+		out:     `SELECT "n" * coalesce($1, 1) + $2`,
+		args:    []interface{}{2, 3},
+	}, {
+		dialect: SQLServer,
+		stmt:    Select(RawArgs("? + ?", 1, 2)).Where(RawArgs("id IN ?", []int{3, 4})),
+		out:     `SELECT @p1 + @p2 WHERE id IN (@p3, @p4)`,
+		args:    []interface{}{1, 2, 3, 4},
+	}, {
+		dialect: Postgres,
+		stmt:    Select(RawArgs(`data ?? 'a?' AND "b?" = ?`, "c")),
+		out:     `SELECT data ? 'a?' AND "b?" = $1`,
+		args:    []interface{}{"c"},
+	}} {
+		t.Run(tt.out, func(t *testing.T) {
+			out, args := tt.stmt.BuildFor(tt.dialect)
+			assertf(t, out == tt.out, "expected %q, got %q", tt.out, out)
+			assertf(t, len(args) == len(tt.args), "expected %d args, got %d", len(tt.args), len(args))
+			minlen := len(args)
+			if len(tt.args) < minlen {
+				minlen = len(tt.args)
+			}
+			for i := 0; i < minlen; i++ {
+				assertf(t, args[i] == tt.args[i], "expected %#v, got %#v", tt.args[i], args[i])
+			}
+		})
+	}
+
+	for _, expr := range []Expression{RawArgs("? = ?", 1), RawArgs("?", 1, 2)} {
+		_, _, err := Select(expr).BuildErr()
+		assertf(t, err != nil, "expected an error")
+	}
+}
@@ -0,0 +1,13 @@
+package build
+
+// Format builds stmt for the dialect d like BuildErrFor, but renders each
+// clause on its own line, and indents subqueries, CTEs, joins and CASE
+// expressions.
+func Format(stmt Expression, d Dialect) (string, []interface{}, error) {
+	b := &builder{dialect: d, pretty: true}
+	stmt.build(b)
+	if b.errs != nil {
+		return "", nil, &BuildError{Errs: b.errs}
+	}
+	return b.buf.String(), b.params, nil
+}
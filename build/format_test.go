@@ -0,0 +1,112 @@
+package build
+
+import "testing"
+
+func TestFormat(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		stmt Expression
+		out  string
+		args []interface{}
+	}{{
+		name: "select",
+		stmt: Select(Ident("a"), Ident("b")).
+			From(Ident("t")).
+			Where(Ident("a").Equal(Bind(1))).
+			OrderBy(Ident("b")).
+			Limit(Int(10)),
+		out: `SELECT "a", "b"
+FROM "t"
+WHERE "a" = $1
+ORDER BY "b"
+LIMIT 10`,
+		args: []interface{}{1},
+	}, {
+		name: "cte",
+		stmt: With("z", Select(Star).From(Ident("t")).Where(Ident("a").Equal(Int(1)))).
+			Select(Star).From(Ident("z")),
+		out: `WITH "z" AS (
+  SELECT *
+  FROM "t"
+  WHERE "a" = 1
+)
+SELECT *
+FROM "z"`,
+	}, {
+		name: "join",
+		stmt: Select(Star).From(
+			FromItem(Ident("a")).Join(Ident("b")).On(Ident("a.id").Equal(Ident("b.id"))).
+				LeftJoin(FromExpr(Select(Star).From(Ident("c"))).As("c")).Using("id"),
+		),
+		out: `SELECT *
+FROM "a"
+  JOIN "b" ON "a"."id" = "b"."id"
+  LEFT JOIN (
+    SELECT *
+    FROM "c"
+  ) AS "c" USING ("id")`,
+	}, {
+		name: "case",
+		stmt: Select(CaseWhen(Ident("a").Equal(Int(1)), String("one")).Else(String("other"))).From(Ident("t")),
+		out: `SELECT CASE
+  WHEN "a" = 1 THEN 'one'
+  ELSE 'other'
+END
+FROM "t"`,
+	}, {
+		name: "subquery",
+		stmt: Select(Star).From(Ident("t")).Where(Ident("id").In(Select(Ident("id")).From(Ident("u")))),
+		out: `SELECT *
+FROM "t"
+WHERE "id" IN (
+  SELECT "id"
+  FROM "u"
+)`,
+	}, {
+		name: "compound",
+		stmt: Union(Select(Int(1)), Select(Int(2))).OrderBy(Int(1)),
+		out: `(
+  SELECT 1
+)
+UNION
+(
+  SELECT 2
+)
+ORDER BY 1`,
+	}, {
+		name: "insert",
+		stmt: InsertInto("t", "a").Values(Bind(1)).Returning(Ident("id")),
+		out: `INSERT INTO "t" ("a")
+VALUES ($1)
+RETURNING "id"`,
+		args: []interface{}{1},
+	}, {
+		name: "update",
+		stmt: Update("t").Set(Assign("a", Bind(1))).Where(Ident("id").Equal(Int(2))),
+		out: `UPDATE "t"
+SET "a" = $1
+WHERE "id" = 2`,
+		args: []interface{}{1},
+	}, {
+		name: "delete",
+		stmt: DeleteFrom("t").Where(Ident("id").Equal(Int(2))).Returning(Star),
+		out: `DELETE FROM "t"
+WHERE "id" = 2
+RETURNING *`,
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			out, args, err := Format(tt.stmt, Postgres)
+			assertf(t, err == nil, "expected no error, got %v", err)
+			assertf(t, out == tt.out, "expected\n%s\ngot\n%s", tt.out, out)
+			assertf(t, len(args) == len(tt.args), "expected %d args, got %d", len(tt.args), len(args))
+			for i := 0; i < len(args) && i < len(tt.args); i++ {
+				assertf(t, args[i] == tt.args[i], "expected %#v, got %#v", tt.args[i], args[i])
+			}
+		})
+	}
+}
+
+func TestFormatErr(t *testing.T) {
+	_, _, err := Format(Update("t"), Postgres)
+	assertf(t, err != nil, "expected an error")
+}
@@ -1,7 +1,5 @@
 package build
 
-import "fmt"
-
 // InsertInto returns a new INSERT statement.
 func InsertInto(table string, columns ...string) *InsertStmt {
 	stmt := &InsertStmt{table: Ident(table)}
@@ -78,6 +76,21 @@ func (stmt *InsertStmt) OnConflictTarget(target Expression, action ConflictActio
 	return stmt
 }
 
+// OnConstraint returns a conflict target naming the constraint name, for
+// OnConflictTarget.
+func OnConstraint(name string) Expression {
+	return onconstraint{name: identifier(name)}
+}
+
+type onconstraint struct {
+	name identifier
+}
+
+func (e onconstraint) build(b *builder) {
+	b.write("ON CONSTRAINT ")
+	e.name.build(b)
+}
+
 // ConflictTarget returns a new ConflictTargetExpr.
 func ConflictTarget(columns ...string) ConflictTargetExpr {
 	exprs := make([]Expression, 0, len(columns))
@@ -90,15 +103,29 @@ func ConflictTarget(columns ...string) ConflictTargetExpr {
 // A ConflictTargetExpr is a conflict target expression.
 type ConflictTargetExpr struct {
 	exprs []Expression
+	where Expression
+}
+
+// Where adds an index predicate to e, to infer a partial unique index. If
+// cond is nil, the predicate is omitted.
+func (e ConflictTargetExpr) Where(cond Expression) ConflictTargetExpr {
+	e.where = cond
+	return e
 }
 
 func (e ConflictTargetExpr) build(b *builder) {
+	b.write("(")
 	for i := range e.exprs {
 		if i != 0 {
 			b.write(", ")
 		}
 		e.exprs[i].build(b)
 	}
+	b.write(")")
+	if !isNil(e.where) {
+		b.write(" WHERE ")
+		e.where.build(b)
+	}
 }
 
 type onconflictexpr struct {
@@ -108,9 +135,14 @@ type onconflictexpr struct {
 
 func (e onconflictexpr) build(b *builder) {
 	b.write("ON CONFLICT ")
-	if e.target != nil {
+	switch target := e.target.(type) {
+	case nil:
+	case ConflictTargetExpr, onconstraint:
+		target.build(b)
+		b.write(" ")
+	default:
 		b.write("(")
-		e.target.build(b)
+		target.build(b)
 		b.write(") ")
 	}
 	e.action.build(b)
@@ -120,24 +152,43 @@ func (e onconflictexpr) build(b *builder) {
 type ConflictAction struct {
 	do     conflictactiondo
 	values []Assignment
+	where  Expression
+}
+
+// Where adds a WHERE clause to the DO UPDATE SET conflict action a, so that
+// only the rows satisfying cond are updated. If cond is nil, the WHERE clause
+// is omitted.
+func (a ConflictAction) Where(cond Expression) ConflictAction {
+	a.where = cond
+	return a
 }
 
 func (a ConflictAction) build(b *builder) {
 	switch do := a.do; do {
 	case donothing:
 		b.write("DO NOTHING")
+		if !isNil(a.where) {
+			b.errorf("DO NOTHING conflict action has a WHERE clause")
+		}
 	case doupdateset:
 		b.write("DO UPDATE SET ")
+		if len(a.values) == 0 {
+			b.errorf("DO UPDATE SET conflict action has no assignments")
+		}
 		for i := range a.values {
 			if i > 0 {
 				b.write(", ")
 			}
 			a.values[i].columnname.build(b)
 			b.write(" = ")
-			a.values[i].expr.build(b)
+			buildOperand(b, a.values[i].expr, false)
+		}
+		if !isNil(a.where) {
+			b.write(" WHERE ")
+			a.where.build(b)
 		}
 	default:
-		panic(fmt.Sprintf("unknown conflict action %d", do))
+		b.errorf("unknown conflict action %d", do)
 	}
 }
 
@@ -152,6 +203,19 @@ func DoUpdateSet(values ...Assignment) ConflictAction {
 	}
 }
 
+// Excluded returns the column of the EXCLUDED table, that holds the row
+// proposed for insertion in a DO UPDATE SET conflict action.
+func Excluded(column string) *InfixExpr {
+	return &InfixExpr{left: excluded(column)}
+}
+
+type excluded string
+
+func (e excluded) build(b *builder) {
+	b.write("EXCLUDED.")
+	identifier(e).build(b)
+}
+
 type conflictactiondo int
 
 const (
@@ -165,44 +229,70 @@ func (stmt *InsertStmt) Returning(exprs ...Expression) *InsertStmt {
 	return stmt
 }
 
-// Build builds stmt and its parameters.
+// Build builds stmt and its parameters. Build panics if stmt is invalid.
 func (stmt *InsertStmt) Build() (string, []interface{}) {
-	b := new(builder)
-	stmt.build(b)
-	return b.buf.String(), b.params
+	return mustBuildStmt(stmt, Postgres)
+}
+
+// BuildFor builds stmt and its parameters for the dialect d. BuildFor panics
+// if stmt is invalid.
+func (stmt *InsertStmt) BuildFor(d Dialect) (string, []interface{}) {
+	return mustBuildStmt(stmt, d)
+}
+
+// BuildErr builds stmt and its parameters. If stmt is invalid, BuildErr
+// returns a *BuildError.
+func (stmt *InsertStmt) BuildErr() (string, []interface{}, error) {
+	return buildStmt(stmt, Postgres)
+}
+
+// BuildErrFor builds stmt and its parameters for the dialect d. If stmt is
+// invalid, BuildErrFor returns a *BuildError.
+func (stmt *InsertStmt) BuildErrFor(d Dialect) (string, []interface{}, error) {
+	return buildStmt(stmt, d)
 }
 
 func (stmt *InsertStmt) build(b *builder) {
+	if stmt.ctes != nil {
+		stmt.ctes.build(b)
+	}
+
 	b.write("INSERT INTO ")
 	stmt.table.build(b)
-	b.write(" ")
 
 	if stmt.columns != nil {
-		b.write("(")
+		b.write(" (")
 		for i := range stmt.columns {
 			if i > 0 {
 				b.write(", ")
 			}
 			stmt.columns[i].build(b)
 		}
-		b.write(") ")
+		b.write(")")
 	}
+	b.clause()
 
-	stmt.valueslist.build(b)
+	if stmt.valueslist == nil {
+		b.errorf("INSERT statement has no values")
+	} else {
+		stmt.valueslist.build(b)
+	}
 
 	if stmt.onconflict != nil {
-		b.write(" ")
+		b.clause()
 		stmt.onconflict.build(b)
 	}
 
 	if stmt.returning != nil {
-		b.write(" RETURNING ")
+		b.clause()
+		b.write("RETURNING ")
 		stmt.returning.build(b)
 	}
 }
 
 // A InsertStmt is a INSERT statement.
 type InsertStmt struct {
+	ctes       *CTEs
 	table      Expression
 	columns    []Expression
 	valueslist Expression
@@ -232,7 +322,7 @@ func (v Values) build(b *builder) {
 		if i > 0 {
 			b.write(", ")
 		}
-		v[i].build(b)
+		buildOperand(b, v[i], false)
 	}
 	b.write(")")
 }
@@ -69,6 +69,34 @@ func TestInsert(t *testing.T) {
 			)),
 		out:  `INSERT INTO "table" ("foo", "bar") VALUES ($1, $2) ON CONFLICT DO UPDATE SET "foo" = $3, "bar" = $4`,
 		args: []interface{}{"hello", 1, "hello", 1},
+	}, {
+		stmt: InsertInto("table", "foo", "bar").
+			Values(Bind("hello"), Bind(1)).
+			OnConflictTarget(OnConstraint("table_pkey"), DoNothing),
+		out:  `INSERT INTO "table" ("foo", "bar") VALUES ($1, $2) ON CONFLICT ON CONSTRAINT "table_pkey" DO NOTHING`,
+		args: []interface{}{"hello", 1},
+	}, {
+		stmt: InsertInto("table", "foo", "bar").
+			Values(Bind("hello"), Bind(1)).
+			OnConflictTarget(ConflictTarget("foo").Where(Ident("deleted_at").IsNull()), DoNothing),
+		out:  `INSERT INTO "table" ("foo", "bar") VALUES ($1, $2) ON CONFLICT ("foo") WHERE "deleted_at" IS NULL DO NOTHING`,
+		args: []interface{}{"hello", 1},
+	}, {
+		stmt: InsertInto("table", "foo", "bar").
+			Values(Bind("hello"), Bind(1)).
+			OnConflictTarget(ConflictTarget("foo"), DoUpdateSet(
+				Assign("bar", Excluded("bar")),
+			).Where(Ident("table.bar").IsDistinctFrom(Excluded("bar")))),
+		out:  `INSERT INTO "table" ("foo", "bar") VALUES ($1, $2) ON CONFLICT ("foo") DO UPDATE SET "bar" = EXCLUDED."bar" WHERE "table"."bar" IS DISTINCT FROM EXCLUDED."bar"`,
+		args: []interface{}{"hello", 1},
+	}, {
+		stmt: InsertInto("table", "foo").
+			Values(Bind("hello")).
+			OnConflictTarget(ConflictTarget("foo").Where(And()), DoUpdateSet(
+				Assign("foo", Excluded("foo")),
+			).Where(And())),
+		out:  `INSERT INTO "table" ("foo") VALUES ($1) ON CONFLICT ("foo") DO UPDATE SET "foo" = EXCLUDED."foo"`,
+		args: []interface{}{"hello"},
 	}} {
 		t.Run(tt.out, func(t *testing.T) {
 			out, args := tt.stmt.Build()
@@ -0,0 +1,181 @@
+package build
+
+import (
+	"database/sql/driver"
+	"encoding/hex"
+	"math"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// String returns s with its parameters inlined, for debugging and logging.
+// The result must never be executed.
+func (s *SelectStmt) String() string { return stringify(s) }
+
+// Interpolate builds s for the dialect d with its parameters inlined, for
+// debugging and logging. The result must never be executed.
+func (s *SelectStmt) Interpolate(d Dialect) (string, error) { return interpolate(s, d) }
+
+// String returns c with its parameters inlined, for debugging and logging.
+// The result must never be executed.
+func (c *CompoundStmt) String() string { return stringify(c) }
+
+// Interpolate builds c for the dialect d with its parameters inlined, for
+// debugging and logging. The result must never be executed.
+func (c *CompoundStmt) Interpolate(d Dialect) (string, error) { return interpolate(c, d) }
+
+// String returns stmt with its parameters inlined, for debugging and
+// logging. The result must never be executed.
+func (stmt *InsertStmt) String() string { return stringify(stmt) }
+
+// Interpolate builds stmt for the dialect d with its parameters inlined, for
+// debugging and logging. The result must never be executed.
+func (stmt *InsertStmt) Interpolate(d Dialect) (string, error) { return interpolate(stmt, d) }
+
+// String returns stmt with its parameters inlined, for debugging and
+// logging. The result must never be executed.
+func (stmt *UpdateStmt) String() string { return stringify(stmt) }
+
+// Interpolate builds stmt for the dialect d with its parameters inlined, for
+// debugging and logging. The result must never be executed.
+func (stmt *UpdateStmt) Interpolate(d Dialect) (string, error) { return interpolate(stmt, d) }
+
+// String returns stmt with its parameters inlined, for debugging and
+// logging. The result must never be executed.
+func (stmt *DeleteStmt) String() string { return stringify(stmt) }
+
+// Interpolate builds stmt for the dialect d with its parameters inlined, for
+// debugging and logging. The result must never be executed.
+func (stmt *DeleteStmt) Interpolate(d Dialect) (string, error) { return interpolate(stmt, d) }
+
+// String returns stmt with its parameters inlined, for debugging and
+// logging. The result must never be executed.
+func (stmt *MergeStmt) String() string { return stringify(stmt) }
+
+// Interpolate builds stmt for the dialect d with its parameters inlined, for
+// debugging and logging. The result must never be executed.
+func (stmt *MergeStmt) Interpolate(d Dialect) (string, error) { return interpolate(stmt, d) }
+
+func interpolate(stmt Expression, d Dialect) (string, error) {
+	b := &builder{dialect: d, inline: true}
+	stmt.build(b)
+	if b.errs != nil {
+		return "", &BuildError{Errs: b.errs}
+	}
+	return b.buf.String(), nil
+}
+
+func stringify(stmt Expression) string {
+	s, err := interpolate(stmt, Postgres)
+	if err != nil {
+		return err.Error()
+	}
+	return s
+}
+
+// writeLiteral writes value as a literal. Named parameters are written as
+// :name.
+func (b *builder) writeLiteral(value interface{}) {
+	switch v := value.(type) {
+	case nil:
+		b.write("NULL")
+	case namedParam:
+		b.write(":" + string(v))
+	case driver.Valuer:
+		if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr && rv.IsNil() {
+			b.write("NULL")
+			return
+		}
+		dv, err := v.Value()
+		if err != nil {
+			b.errorf("can't get value of %#v: %w", v, err)
+			return
+		}
+		if _, ok := dv.(driver.Valuer); ok {
+			b.errorf("value of %#v is a driver.Valuer", v)
+			return
+		}
+		b.writeLiteral(dv)
+	case time.Time:
+		if b.dialect.Supports(TimeZoneLiterals) {
+			b.write(b.quoteString(v.Format("2006-01-02 15:04:05.999999Z07:00")))
+		} else {
+			b.write(b.quoteString(v.Format("2006-01-02 15:04:05.999999")))
+		}
+	case []byte:
+		switch {
+		case b.dialect.Supports(BinaryLiterals):
+			b.write("X'" + hex.EncodeToString(v) + "'")
+		case b.dialect.Supports(HexLiterals):
+			b.write("0x" + hex.EncodeToString(v))
+		default:
+			b.write("decode('" + hex.EncodeToString(v) + "', 'hex')")
+		}
+	default:
+		b.writeReflectLiteral(reflect.ValueOf(value))
+	}
+}
+
+func (b *builder) writeReflectLiteral(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Bool:
+		switch {
+		case b.dialect.Supports(BoolLiterals):
+			b.write(strconv.FormatBool(v.Bool()))
+		case v.Bool():
+			b.write("1")
+		default:
+			b.write("0")
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		b.write(strconv.FormatInt(v.Int(), 10))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		b.write(strconv.FormatUint(v.Uint(), 10))
+	case reflect.Float32, reflect.Float64:
+		f := v.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			if !b.dialect.Supports(NaNLiterals) {
+				b.errorf("can't interpolate %v with the dialect", f)
+				return
+			}
+			switch {
+			case math.IsNaN(f):
+				b.write("'NaN'")
+			case f > 0:
+				b.write("'Infinity'")
+			default:
+				b.write("'-Infinity'")
+			}
+			return
+		}
+		b.write(strconv.FormatFloat(f, 'g', -1, v.Type().Bits()))
+	case reflect.String:
+		b.write(b.quoteString(v.String()))
+	case reflect.Ptr:
+		if v.IsNil() {
+			b.write("NULL")
+			return
+		}
+		b.writeLiteral(v.Elem().Interface())
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			b.writeLiteral(v.Bytes())
+			return
+		}
+		if !b.dialect.Supports(ArrayLiterals) {
+			b.errorf("can't interpolate array %T with the dialect", v.Interface())
+			return
+		}
+		b.write("ARRAY[")
+		for i := 0; i < v.Len(); i++ {
+			if i > 0 {
+				b.write(", ")
+			}
+			b.writeLiteral(v.Index(i).Interface())
+		}
+		b.write("]")
+	default:
+		b.errorf("can't interpolate value %#v (%T)", v.Interface(), v.Interface())
+	}
+}
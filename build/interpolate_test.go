@@ -0,0 +1,93 @@
+package build
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestInterpolate(t *testing.T) {
+	date := time.Date(2020, 1, 2, 3, 4, 5, 6000, time.UTC)
+	name := "O'Brien"
+	var nilname *string
+	for _, tt := range []struct {
+		dialect Dialect
+		stmt    interface {
+			Interpolate(Dialect) (string, error)
+		}
+		out string
+	}{{
+		dialect: Postgres,
+		stmt: Select(Star).From(Ident("users")).
+			Where(Ident("name").Equal(Bind(name)).
+				And(Ident("created_at").LessThan(Bind(date))).
+				And(Ident("id").In(Bind([]int{1, 2}))).
+				And(Ident("data").Equal(Bind([]byte{0xde, 0xad}))).
+				And(Ident("score").GreaterThan(Bind(1.5))).
+				And(Ident("active").Equal(Bind(true))).
+				And(Ident("deleted_at").IsNotDistinctFrom(Bind(nil))).
+				And(Ident("nickname").IsNotDistinctFrom(Bind(nilname))).
+				And(Ident("status").Equal(Bind(status("done")))).
+				And(Ident("tags").Op("&&", BindArray([]string{"a", "b"}))).
+				And(Ident("org_id").Equal(Param("org_id"))).
+				And(Ident("v").Equal(Bind(valuer(42))))),
+		out: `SELECT * FROM "users" WHERE "name" = 'O''Brien' AND "created_at" < '2020-01-02 03:04:05.000006Z' AND "id" IN (1, 2) AND "data" = decode('dead', 'hex') AND "score" > 1.5 AND "active" = true AND "deleted_at" IS NOT DISTINCT FROM NULL AND "nickname" IS NOT DISTINCT FROM NULL AND "status" = 'done' AND "tags" && ARRAY['a', 'b'] AND "org_id" = :org_id AND "v" = 42`,
+	}, {
+		dialect: MySQL,
+		stmt:    Update("users").Set(Assign("name", Bind(`a\b`)), Assign("data", Bind([]byte{1}))).Where(Ident("created_at").LessThan(Bind(date))),
+		out:     "UPDATE `users` SET `name` = 'a\\\\b', `data` = X'01' WHERE `created_at` < '2020-01-02 03:04:05.000006'",
+	}, {
+		dialect: SQLServer,
+		stmt:    InsertInto("users", "active", "data").Values(Bind(false), Bind([]byte{1})),
+		out:     `INSERT INTO [users] ([active], [data]) VALUES (0, 0x01)`,
+	}, {
+		dialect: SQLite,
+		stmt:    DeleteFrom("users").Where(Ident("id").Equal(Bind(&name))),
+		out:     `DELETE FROM "users" WHERE "id" = 'O''Brien'`,
+	}, {
+		dialect: Postgres,
+		stmt:    Select(Bind(math.NaN()), Bind(math.Inf(1)), Bind(float32(math.Inf(-1)))),
+		out:     `SELECT 'NaN', 'Infinity', '-Infinity'`,
+	}, {
+		dialect: noFeatures{Postgres},
+		stmt:    Select(Bind(true), Bind(date), Bind([]byte{1})),
+		out:     `SELECT 1, '2020-01-02 03:04:05.000006', decode('01', 'hex')`,
+	}} {
+		t.Run(tt.out, func(t *testing.T) {
+			out, err := tt.stmt.Interpolate(tt.dialect)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertf(t, out == tt.out, "expected %q, got %q", tt.out, out)
+		})
+	}
+}
+
+func TestInterpolateUnsupported(t *testing.T) {
+	for _, tt := range []struct {
+		dialect Dialect
+		stmt    *SelectStmt
+	}{
+		{dialect: MySQL, stmt: Select(Bind(math.NaN()))},
+		{dialect: SQLite, stmt: Select(Bind(math.Inf(1)))},
+		{dialect: MySQL, stmt: Select(BindArray([]int{1, 2}))},
+		{dialect: SQLite, stmt: Select(BindArray([]string{"a"}))},
+		{dialect: SQLServer, stmt: Select(BindArray([]int{1}))},
+	} {
+		_, err := tt.stmt.Interpolate(tt.dialect)
+		assertf(t, err != nil, "expected an error")
+	}
+}
+
+func TestString(t *testing.T) {
+	stmt := Select(Star).From(Ident("users")).Where(Ident("name").Equal(Bind("foo"))).Limit(Bind(10))
+	expected := `SELECT * FROM "users" WHERE "name" = 'foo' LIMIT 10`
+	assertf(t, stmt.String() == expected, "expected %q, got %q", expected, stmt.String())
+
+	query, args := stmt.Build()
+	assertf(t, query == `SELECT * FROM "users" WHERE "name" = $1 LIMIT $2`, "expected Build to be unchanged, got %q", query)
+	assertf(t, len(args) == 2, "expected 2 args, got %d", len(args))
+
+	_, err := Select(Bind(struct{}{})).Interpolate(Postgres)
+	assertf(t, err != nil, "expected an error")
+}
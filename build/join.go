@@ -10,6 +10,10 @@ type FromItemExpr interface {
 	Expression
 	Join(expr Expression) JoinExpr
 	LeftJoin(expr Expression) JoinExpr
+	RightJoin(expr Expression) JoinExpr
+	FullJoin(expr Expression) JoinExpr
+	CrossJoin(expr Expression) FromItemExpr
+	NaturalJoin(expr Expression) FromItemExpr
 }
 
 type fromItemExpr struct {
@@ -24,19 +28,37 @@ func (e *fromItemExpr) LeftJoin(right Expression) JoinExpr {
 	return &joinExpr{left: e, jointype: "LEFT", right: right}
 }
 
+func (e *fromItemExpr) RightJoin(right Expression) JoinExpr {
+	return &joinExpr{left: e, jointype: "RIGHT", right: right}
+}
+
+func (e *fromItemExpr) FullJoin(right Expression) JoinExpr {
+	return &joinExpr{left: e, jointype: "FULL", right: right}
+}
+
+func (e *fromItemExpr) CrossJoin(right Expression) FromItemExpr {
+	return &joinExpr{left: e, jointype: "CROSS", right: right}
+}
+
+func (e *fromItemExpr) NaturalJoin(right Expression) FromItemExpr {
+	return &joinExpr{left: e, jointype: "NATURAL", right: right}
+}
+
 func (e *fromItemExpr) build(b *builder) {
-	e.expr.build(b)
+	buildFromItem(b, e.expr)
 }
 
 // A JoinExpr is a FROM item expression with a JOIN.
 type JoinExpr interface {
 	FromItemExpr
 	On(on Expression) FromItemExpr
+	Using(columns ...string) FromItemExpr
 }
 
 type joinExpr struct {
 	jointype        string
 	left, right, on Expression
+	using           []Expression
 }
 
 func (e *joinExpr) LeftJoin(right Expression) JoinExpr {
@@ -47,20 +69,153 @@ func (e *joinExpr) Join(right Expression) JoinExpr {
 	return &joinExpr{left: e, right: right}
 }
 
+func (e *joinExpr) RightJoin(right Expression) JoinExpr {
+	return &joinExpr{left: e, jointype: "RIGHT", right: right}
+}
+
+func (e *joinExpr) FullJoin(right Expression) JoinExpr {
+	return &joinExpr{left: e, jointype: "FULL", right: right}
+}
+
+func (e *joinExpr) CrossJoin(right Expression) FromItemExpr {
+	return &joinExpr{left: e, jointype: "CROSS", right: right}
+}
+
+func (e *joinExpr) NaturalJoin(right Expression) FromItemExpr {
+	return &joinExpr{left: e, jointype: "NATURAL", right: right}
+}
+
 func (e *joinExpr) On(on Expression) FromItemExpr {
 	e.on = on
 	return e
 }
 
+func (e *joinExpr) Using(columns ...string) FromItemExpr {
+	e.using = make([]Expression, 0, len(columns))
+	for i := range columns {
+		e.using = append(e.using, identifier(columns[i]))
+	}
+	return e
+}
+
 func (e *joinExpr) build(b *builder) {
 	e.left.build(b)
+	b.depth++
+	b.clause()
 	if e.jointype != "" {
-		b.write(" " + e.jointype)
+		b.write(e.jointype + " ")
+	}
+	b.write("JOIN ")
+	buildFromItem(b, e.right)
+	switch e.jointype {
+	case "CROSS", "NATURAL":
+	default:
+		if isNil(e.on) && e.using == nil {
+			b.errorf("JOIN has no ON or USING clause")
+		}
+		if !isNil(e.on) && e.using != nil {
+			b.errorf("JOIN has both ON and USING clauses")
+		}
 	}
-	b.write(" JOIN ")
-	e.right.build(b)
-	if e.on != nil {
+	if !isNil(e.on) {
 		b.write(" ON ")
 		e.on.build(b)
 	}
+	if e.using != nil {
+		b.write(" USING (")
+		for i := range e.using {
+			if i > 0 {
+				b.write(", ")
+			}
+			e.using[i].build(b)
+		}
+		b.write(")")
+	}
+	b.depth--
+}
+
+// Lateral returns a new LATERAL FROM item, which can reference columns of the
+// preceding FROM items.
+func Lateral(expr Expression) AsExpr {
+	return lateralExpr{asExpr: asExpr{expr: expr}}
+}
+
+type lateralExpr struct{ asExpr }
+
+func (e lateralExpr) As(alias string) Expression {
+	return lateralExpr{asExpr: asExpr{expr: e.expr, alias: identifier(alias)}}
+}
+
+func (e lateralExpr) build(b *builder) {
+	b.write("LATERAL ")
+	e.asExpr.build(b)
+}
+
+// ValuesTable returns a new VALUES list of rows, which can be used as a FROM
+// item once aliased with As.
+func ValuesTable(rows ...Values) *ValuesTableExpr {
+	return &ValuesTableExpr{rows: rows}
+}
+
+// A ValuesTableExpr is a VALUES list.
+type ValuesTableExpr struct {
+	rows []Values
+}
+
+// As returns e aliased as alias, with the column names columns.
+func (e *ValuesTableExpr) As(alias string, columns ...string) Expression {
+	a := valuesAliasExpr{values: e, alias: identifier(alias)}
+	for i := range columns {
+		a.columns = append(a.columns, identifier(columns[i]))
+	}
+	return a
+}
+
+func (e *ValuesTableExpr) build(b *builder) {
+	if len(e.rows) == 0 {
+		b.errorf("VALUES list has no rows")
+	}
+	valueslistexpr{valueslist: e.rows}.build(b)
+}
+
+type valuesAliasExpr struct {
+	values  Expression
+	alias   identifier
+	columns []Expression
+}
+
+func (e valuesAliasExpr) build(b *builder) {
+	b.subquery(e.values)
+	b.write(" AS ")
+	e.alias.build(b)
+	if e.columns != nil {
+		b.write(" (")
+		for i := range e.columns {
+			if i > 0 {
+				b.write(", ")
+			}
+			e.columns[i].build(b)
+		}
+		b.write(")")
+	}
+}
+
+// buildFromItem builds a FROM item, with parentheses around subqueries.
+// Subqueries must have an alias.
+func buildFromItem(b *builder, expr Expression) {
+	var aliased asExpr
+	switch e := expr.(type) {
+	case asExpr:
+		aliased = e
+	case lateralExpr:
+		aliased = e.asExpr
+	}
+	if isQuery(expr) || (isQuery(aliased.expr) && aliased.alias == "") {
+		b.errorf("subquery in FROM clause has no alias")
+	}
+	if isQuery(expr) {
+		b.subquery(expr)
+		return
+	}
+	expr.build(b)
 }
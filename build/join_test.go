@@ -28,6 +28,50 @@ func TestJoin(t *testing.T) {
 				CallExpr("date_trunc", String("month"), Ident("t1.foo")).Equal(Ident("t2.bar")),
 			)),
 		out: `SELECT "t1"."foo", "t2"."bar" FROM "t1" JOIN "t2" ON date_trunc('month', "t1"."foo") = "t2"."bar"`,
+	}, {
+		stmt: Select(Star).
+			From(FromItem(Ident("t1")).RightJoin(Ident("t2")).On(Ident("t1.id").Equal(Ident("t2.id")))),
+		out: `SELECT * FROM "t1" RIGHT JOIN "t2" ON "t1"."id" = "t2"."id"`,
+	}, {
+		stmt: Select(Star).
+			From(FromItem(Ident("t1")).FullJoin(Ident("t2")).Using("id", "name")),
+		out: `SELECT * FROM "t1" FULL JOIN "t2" USING ("id", "name")`,
+	}, {
+		stmt: Select(Star).
+			From(FromItem(Ident("t1")).CrossJoin(Ident("t2")).NaturalJoin(Ident("t3"))),
+		out: `SELECT * FROM "t1" CROSS JOIN "t2" NATURAL JOIN "t3"`,
+	}, {
+		stmt: Select(Star).
+			From(FromItem(Ident("t1")).Join(
+				FromExpr(Select(Ident("id")).From(Ident("t2")).Where(Ident("active"))).As("t2"),
+			).Using("id")),
+		out: `SELECT * FROM "t1" JOIN (SELECT "id" FROM "t2" WHERE "active") AS "t2" USING ("id")`,
+	}, {
+		stmt: Select(Star).
+			From(FromItem(FromExpr(Select(Ident("id")).From(Ident("t1"))).As("a")).Join(FromExpr(Select(Ident("id")).From(Ident("t2"))).As("b")).Using("id")),
+		out: `SELECT * FROM (SELECT "id" FROM "t1") AS "a" JOIN (SELECT "id" FROM "t2") AS "b" USING ("id")`,
+	}, {
+		stmt: Select(Columns("m.name", "top.title")...).
+			From(FromItem(FromExpr(Ident("manufacturers")).As("m")).LeftJoin(
+				Lateral(Select(Ident("title")).
+					From(Ident("products")).
+					Where(Ident("products.manufacturer_id").Equal(Ident("m.id"))).
+					OrderBy(Order(Ident("price"), Desc)).
+					Limit(Bind(3)),
+				).As("top"),
+			).On(Bool(true))),
+		out:  `SELECT "m"."name", "top"."title" FROM "manufacturers" AS "m" LEFT JOIN LATERAL (SELECT "title" FROM "products" WHERE "products"."manufacturer_id" = "m"."id" ORDER BY "price" DESC LIMIT $1) AS "top" ON true`,
+		args: []interface{}{3},
+	}, {
+		stmt: Select(Star).From(
+			Ident("t1"),
+			Lateral(CallExpr("generate_series", Int(1), Ident("t1.n"))).As("s"),
+		),
+		out: `SELECT * FROM "t1", LATERAL generate_series(1, "t1"."n") AS "s"`,
+	}, {
+		stmt: Select(Star).
+			From(FromItem(Ident("t1")).Join(ValuesTable(Values{Int(1)}, Values{Int(2)}).As("v", "id")).On(Ident("t1.id").Equal(Ident("v.id")))),
+		out: `SELECT * FROM "t1" JOIN (VALUES (1), (2)) AS "v" ("id") ON "t1"."id" = "v"."id"`,
 	}} {
 		t.Run(tt.out, func(t *testing.T) {
 			out, args := tt.stmt.Build()
@@ -0,0 +1,87 @@
+package build
+
+// For adds locking clauses, which lock the rows returned by s. Locking
+// clauses are built after the LIMIT and OFFSET clauses.
+func (s *SelectStmt) For(clauses ...LockingClause) *SelectStmt {
+	s.locking = append(s.locking, clauses...)
+	return s
+}
+
+// ForUpdate returns a new FOR UPDATE locking clause.
+func ForUpdate() LockingClause { return LockingClause{strength: "UPDATE"} }
+
+// ForNoKeyUpdate returns a new FOR NO KEY UPDATE locking clause.
+func ForNoKeyUpdate() LockingClause { return LockingClause{strength: "NO KEY UPDATE"} }
+
+// ForShare returns a new FOR SHARE locking clause.
+func ForShare() LockingClause { return LockingClause{strength: "SHARE"} }
+
+// ForKeyShare returns a new FOR KEY SHARE locking clause.
+func ForKeyShare() LockingClause { return LockingClause{strength: "KEY SHARE"} }
+
+// A LockingClause is a locking clause.
+type LockingClause struct {
+	strength   string
+	of         []Expression
+	nowait     bool
+	skiplocked bool
+}
+
+// Of restricts l to the rows of tables.
+func (l LockingClause) Of(tables ...string) LockingClause {
+	l.of = make([]Expression, 0, len(tables))
+	for i := range tables {
+		l.of = append(l.of, Ident(tables[i]))
+	}
+	return l
+}
+
+// NoWait adds the NOWAIT option to l, so that the statement fails instead of
+// waiting for locked rows.
+func (l LockingClause) NoWait() LockingClause {
+	l.nowait = true
+	return l
+}
+
+// SkipLocked adds the SKIP LOCKED option to l, so that locked rows are
+// skipped.
+func (l LockingClause) SkipLocked() LockingClause {
+	l.skiplocked = true
+	return l
+}
+
+func (l LockingClause) build(b *builder) {
+	b.write("FOR " + l.strength)
+	if l.of != nil {
+		b.write(" OF ")
+		for i := range l.of {
+			if i > 0 {
+				b.write(", ")
+			}
+			l.of[i].build(b)
+		}
+	}
+	if l.nowait && l.skiplocked {
+		b.errorf("locking clause has both NOWAIT and SKIP LOCKED")
+	}
+	if l.nowait {
+		b.write(" NOWAIT")
+	}
+	if l.skiplocked {
+		b.write(" SKIP LOCKED")
+	}
+}
+
+type locking []LockingClause
+
+func (l locking) build(b *builder) {
+	if !b.dialect.Supports(LockingClauses) {
+		b.errorf("locking clauses are not supported by the dialect")
+	}
+	for i := range l {
+		if i > 0 {
+			b.clause()
+		}
+		l[i].build(b)
+	}
+}
@@ -0,0 +1,44 @@
+package build
+
+import "testing"
+
+func TestLocking(t *testing.T) {
+	for _, tt := range []struct {
+		stmt *SelectStmt
+		d    Dialect
+		out  string
+	}{{
+		stmt: Select(Star).From(Ident("jobs")).For(ForUpdate()),
+		d:    Postgres,
+		out:  `SELECT * FROM "jobs" FOR UPDATE`,
+	}, {
+		stmt: Select(Star).From(Ident("jobs")).
+			Where(Ident("state").Equal(String("queued"))).
+			OrderBy(Ident("id")).
+			Limit(Int(1)).
+			For(ForUpdate().SkipLocked()),
+		d:   Postgres,
+		out: `SELECT * FROM "jobs" WHERE "state" = 'queued' ORDER BY "id" LIMIT 1 FOR UPDATE SKIP LOCKED`,
+	}, {
+		stmt: Select(Star).From(Ident("a"), Ident("b")).
+			For(ForNoKeyUpdate().Of("a").NoWait(), ForKeyShare().Of("b")),
+		d:   Postgres,
+		out: `SELECT * FROM "a", "b" FOR NO KEY UPDATE OF "a" NOWAIT FOR KEY SHARE OF "b"`,
+	}, {
+		stmt: Select(Star).From(Ident("jobs")).Limit(Int(1)).For(ForShare().Of("jobs")),
+		d:    MySQL,
+		out:  "SELECT * FROM `jobs` LIMIT 1 FOR SHARE OF `jobs`",
+	}} {
+		t.Run(tt.out, func(t *testing.T) {
+			out, _ := tt.stmt.BuildFor(tt.d)
+			assertf(t, out == tt.out, "expected %q, got %q", tt.out, out)
+		})
+	}
+}
+
+func TestLockingUnsupported(t *testing.T) {
+	for _, d := range []Dialect{SQLite, SQLServer, noFeatures{Postgres}} {
+		_, _, err := Select(Star).From(Ident("jobs")).For(ForUpdate()).BuildErrFor(d)
+		assertf(t, err != nil, "expected an error for %T", d)
+	}
+}
@@ -0,0 +1,226 @@
+package build
+
+// MergeInto returns a new MERGE statement. Building it fails with dialects
+// which don't support MERGE, like MySQL and SQLite.
+func MergeInto(table string) *MergeStmt {
+	return &MergeStmt{table: Ident(table)}
+}
+
+// Using sets the data source of stmt.
+func (stmt *MergeStmt) Using(source Expression) *MergeStmt {
+	stmt.using = source
+	return stmt
+}
+
+// On sets the join condition of stmt.
+func (stmt *MergeStmt) On(condition Expression) *MergeStmt {
+	stmt.on = condition
+	return stmt
+}
+
+// When appends WHEN clauses to stmt. The clauses are evaluated in order.
+func (stmt *MergeStmt) When(clauses ...MergeWhen) *MergeStmt {
+	stmt.whens = append(stmt.whens, clauses...)
+	return stmt
+}
+
+// Build builds stmt and its parameters. Build panics if stmt is invalid.
+func (stmt *MergeStmt) Build() (string, []interface{}) {
+	return mustBuildStmt(stmt, Postgres)
+}
+
+// BuildFor builds stmt and its parameters for the dialect d. BuildFor panics
+// if stmt is invalid.
+func (stmt *MergeStmt) BuildFor(d Dialect) (string, []interface{}) {
+	return mustBuildStmt(stmt, d)
+}
+
+// BuildErr builds stmt and its parameters. If stmt is invalid, BuildErr
+// returns a *BuildError.
+func (stmt *MergeStmt) BuildErr() (string, []interface{}, error) {
+	return buildStmt(stmt, Postgres)
+}
+
+// BuildErrFor builds stmt and its parameters for the dialect d. If stmt is
+// invalid, BuildErrFor returns a *BuildError.
+func (stmt *MergeStmt) BuildErrFor(d Dialect) (string, []interface{}, error) {
+	return buildStmt(stmt, d)
+}
+
+func (stmt *MergeStmt) build(b *builder) {
+	if !b.dialect.Supports(Merge) {
+		b.errorf("MERGE statements are not supported by the dialect")
+	}
+	if stmt.ctes != nil {
+		stmt.ctes.build(b)
+	}
+
+	b.write("MERGE INTO ")
+	stmt.table.build(b)
+
+	b.clause()
+	b.write("USING ")
+	if isNil(stmt.using) {
+		b.errorf("MERGE statement has no data source")
+	} else {
+		buildFromItem(b, stmt.using)
+	}
+
+	b.write(" ON ")
+	if isNil(stmt.on) {
+		b.errorf("MERGE statement has no join condition")
+	} else {
+		stmt.on.build(b)
+	}
+
+	if len(stmt.whens) == 0 {
+		b.errorf("MERGE statement has no WHEN clauses")
+	}
+	for i := range stmt.whens {
+		b.clause()
+		stmt.whens[i].build(b)
+	}
+
+	if b.dialect.Supports(MergeTerminator) {
+		b.write(";")
+	}
+}
+
+// A MergeStmt is a MERGE statement.
+type MergeStmt struct {
+	ctes  *CTEs
+	table Expression
+	using Expression
+	on    Expression
+	whens []MergeWhen
+}
+
+// WhenMatched returns a new WHEN MATCHED clause, for the rows of the target
+// table that match a row of the data source.
+func WhenMatched() MergeWhen {
+	return MergeWhen{matched: true}
+}
+
+// WhenNotMatched returns a new WHEN NOT MATCHED clause, for the rows of the
+// data source that match no row of the target table.
+func WhenNotMatched() MergeWhen {
+	return MergeWhen{}
+}
+
+// And adds a condition to w. If condition is nil, w has no condition.
+func (w MergeWhen) And(condition Expression) MergeWhen {
+	w.condition = condition
+	return w
+}
+
+// ThenUpdate sets the action of w to UPDATE SET assignments.
+func (w MergeWhen) ThenUpdate(assignments ...Assignment) MergeWhen {
+	w.action = mergeupdate
+	w.assignments = assignments
+	return w
+}
+
+// ThenDelete sets the action of w to DELETE.
+func (w MergeWhen) ThenDelete() MergeWhen {
+	w.action = mergedelete
+	return w
+}
+
+// ThenInsert sets the action of w to INSERT values into columns. If columns
+// is empty, values are inserted in the order of the columns of the table.
+func (w MergeWhen) ThenInsert(columns []string, values Values) MergeWhen {
+	w.action = mergeinsert
+	w.columns = make([]Expression, 0, len(columns))
+	for i := range columns {
+		w.columns = append(w.columns, Ident(columns[i]))
+	}
+	w.values = values
+	return w
+}
+
+// ThenDoNothing sets the action of w to DO NOTHING.
+func (w MergeWhen) ThenDoNothing() MergeWhen {
+	w.action = mergedonothing
+	return w
+}
+
+// A MergeWhen is a WHEN clause of a MERGE statement.
+type MergeWhen struct {
+	matched     bool
+	condition   Expression
+	action      mergeaction
+	assignments []Assignment
+	columns     []Expression
+	values      Values
+}
+
+func (w MergeWhen) build(b *builder) {
+	if w.matched {
+		b.write("WHEN MATCHED")
+	} else {
+		b.write("WHEN NOT MATCHED")
+	}
+	if !isNil(w.condition) {
+		b.write(" AND ")
+		w.condition.build(b)
+	}
+	b.write(" THEN ")
+
+	switch action := w.action; action {
+	case mergeupdate:
+		if !w.matched {
+			b.errorf("WHEN NOT MATCHED clause cannot UPDATE")
+		}
+		b.write("UPDATE SET ")
+		if len(w.assignments) == 0 {
+			b.errorf("MERGE UPDATE action has no assignments")
+		}
+		for i := range w.assignments {
+			if i > 0 {
+				b.write(", ")
+			}
+			w.assignments[i].columnname.build(b)
+			b.write(" = ")
+			buildOperand(b, w.assignments[i].expr, false)
+		}
+	case mergedelete:
+		if !w.matched {
+			b.errorf("WHEN NOT MATCHED clause cannot DELETE")
+		}
+		b.write("DELETE")
+	case mergeinsert:
+		if w.matched {
+			b.errorf("WHEN MATCHED clause cannot INSERT")
+		}
+		b.write("INSERT ")
+		if len(w.columns) > 0 {
+			b.write("(")
+			for i := range w.columns {
+				if i > 0 {
+					b.write(", ")
+				}
+				w.columns[i].build(b)
+			}
+			b.write(") ")
+		}
+		if len(w.values) == 0 {
+			b.errorf("MERGE INSERT action has no values")
+		}
+		b.write("VALUES ")
+		w.values.build(b)
+	case mergedonothing:
+		b.write("DO NOTHING")
+	default:
+		b.errorf("MERGE WHEN clause has no action")
+	}
+}
+
+type mergeaction int
+
+const (
+	mergenone mergeaction = iota
+	mergeupdate
+	mergedelete
+	mergeinsert
+	mergedonothing
+)
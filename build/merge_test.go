@@ -0,0 +1,86 @@
+package build
+
+import "testing"
+
+func TestMerge(t *testing.T) {
+	for _, tt := range []struct {
+		stmt *MergeStmt
+		out  string
+		args []interface{}
+	}{{
+		stmt: MergeInto("accounts").
+			Using(Ident("transactions")).
+			On(Ident("accounts.id").Equal(Ident("transactions.account_id"))).
+			When(
+				WhenMatched().ThenUpdate(Assign("balance", Ident("accounts.balance").Add(Ident("transactions.amount")))),
+				WhenNotMatched().ThenInsert([]string{"id", "balance"}, Values{Ident("transactions.account_id"), Ident("transactions.amount")}),
+			),
+		out: `MERGE INTO "accounts" USING "transactions" ON "accounts"."id" = "transactions"."account_id"` +
+			` WHEN MATCHED THEN UPDATE SET "balance" = "accounts"."balance" + "transactions"."amount"` +
+			` WHEN NOT MATCHED THEN INSERT ("id", "balance") VALUES ("transactions"."account_id", "transactions"."amount")`,
+	}, {
+		stmt: MergeInto("accounts").
+			Using(FromExpr(Select(Star).From(Ident("staging")).Where(Ident("batch").Equal(Bind(42)))).As("s")).
+			On(Ident("accounts.id").Equal(Ident("s.id"))).
+			When(
+				WhenMatched().And(Ident("s.deleted")).ThenDelete(),
+				WhenMatched().ThenUpdate(Assign("name", Ident("s.name"))),
+				WhenNotMatched().And(Ident("s.deleted")).ThenDoNothing(),
+				WhenNotMatched().ThenInsert(nil, Values{Ident("s.id"), Ident("s.name")}),
+			),
+		out: `MERGE INTO "accounts" USING (SELECT * FROM "staging" WHERE "batch" = $1) AS "s" ON "accounts"."id" = "s"."id"` +
+			` WHEN MATCHED AND "s"."deleted" THEN DELETE` +
+			` WHEN MATCHED THEN UPDATE SET "name" = "s"."name"` +
+			` WHEN NOT MATCHED AND "s"."deleted" THEN DO NOTHING` +
+			` WHEN NOT MATCHED THEN INSERT VALUES ("s"."id", "s"."name")`,
+		args: []interface{}{42},
+	}, {
+		stmt: With("s", Select(Star).From(Ident("staging"))).
+			MergeInto("accounts").
+			Using(Ident("s")).
+			On(Ident("accounts.id").Equal(Ident("s.id"))).
+			When(WhenMatched().ThenUpdate(Assign("name", Bind("x")))),
+		out:  `WITH "s" AS ( SELECT * FROM "staging" ) MERGE INTO "accounts" USING "s" ON "accounts"."id" = "s"."id" WHEN MATCHED THEN UPDATE SET "name" = $1`,
+		args: []interface{}{"x"},
+	}, {
+		stmt: MergeInto("accounts").
+			Using(Ident("s")).
+			On(Ident("accounts.id").Equal(Ident("s.id"))).
+			When(WhenMatched().And(And()).ThenDelete()),
+		out: `MERGE INTO "accounts" USING "s" ON "accounts"."id" = "s"."id" WHEN MATCHED THEN DELETE`,
+	}} {
+		t.Run(tt.out, func(t *testing.T) {
+			out, args := tt.stmt.Build()
+			assertf(t, out == tt.out, "expected %q, got %q", tt.out, out)
+			assertf(t, len(args) == len(tt.args), "expected %d args, got %d", len(tt.args), len(args))
+			minlen := len(args)
+			if len(tt.args) < minlen {
+				minlen = len(tt.args)
+			}
+			for i := 0; i < minlen; i++ {
+				assertf(t, args[i] == tt.args[i], "expected %#v, got %#v", tt.args[i], args[i])
+			}
+		})
+	}
+}
+
+func TestMergeSQLServer(t *testing.T) {
+	stmt := MergeInto("accounts").
+		Using(Ident("s")).
+		On(Ident("accounts.id").Equal(Ident("s.id"))).
+		When(WhenMatched().ThenUpdate(Assign("name", Bind("x"))))
+	out, _ := stmt.BuildFor(SQLServer)
+	expected := `MERGE INTO [accounts] USING [s] ON [accounts].[id] = [s].[id] WHEN MATCHED THEN UPDATE SET [name] = @p1;`
+	assertf(t, out == expected, "expected %q, got %q", expected, out)
+}
+
+func TestMergeUnsupported(t *testing.T) {
+	stmt := MergeInto("accounts").
+		Using(Ident("s")).
+		On(Ident("accounts.id").Equal(Ident("s.id"))).
+		When(WhenMatched().ThenDelete())
+	for _, d := range []Dialect{MySQL, SQLite, noFeatures{Postgres}} {
+		_, _, err := stmt.BuildErrFor(d)
+		assertf(t, err != nil, "expected an error")
+	}
+}
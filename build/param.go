@@ -0,0 +1,173 @@
+package build
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// Param returns a named parameter. Its value is not known when building the
+// statement: the parameters returned by Build hold a placeholder value, which
+// is replaced with BindParams. The value of a named parameter is always bound
+// as a single parameter, even if it is a slice.
+func Param(name string) *InfixExpr {
+	return &InfixExpr{left: namedParam(name)}
+}
+
+type namedParam string
+
+func (p namedParam) build(b *builder) {
+	b.param(p)
+}
+
+// Value implements driver.Valuer, so that executing a statement whose named
+// parameters were not replaced with BindParams fails.
+func (p namedParam) Value() (driver.Value, error) {
+	return nil, fmt.Errorf("build: parameter %q is not bound", string(p))
+}
+
+// BindParams returns a copy of args, where the named parameters are replaced
+// with their values. values must be a map with string keys, a struct or a
+// pointer to a struct. The value of a named parameter is read from the struct
+// field with the tag `param:"name"`, or from the untagged struct field named
+// name. The fields of embedded structs are promoted like in Go.
+func BindParams(args []interface{}, values interface{}) ([]interface{}, error) {
+	lookup, err := paramLookup(values)
+	if err != nil {
+		return nil, err
+	}
+	bound := make([]interface{}, len(args))
+	for i := range args {
+		p, ok := args[i].(namedParam)
+		if !ok {
+			bound[i] = args[i]
+			continue
+		}
+		value, ok := lookup(string(p))
+		if !ok {
+			return nil, fmt.Errorf("build: missing value for parameter %q", string(p))
+		}
+		bound[i] = value
+	}
+	return bound, nil
+}
+
+func paramLookup(values interface{}) (func(string) (interface{}, bool), error) {
+	if m, ok := values.(map[string]interface{}); ok {
+		return func(name string) (interface{}, bool) {
+			value, ok := m[name]
+			return value, ok
+		}, nil
+	}
+
+	v := reflect.ValueOf(values)
+	if v.Kind() == reflect.Ptr && !v.IsNil() {
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			break
+		}
+		return func(name string) (interface{}, bool) {
+			value := v.MapIndex(reflect.ValueOf(name).Convert(v.Type().Key()))
+			if !value.IsValid() {
+				return nil, false
+			}
+			return value.Interface(), true
+		}, nil
+	case reflect.Struct:
+		fields := paramFields(v.Type())
+		return func(name string) (interface{}, bool) {
+			index, ok := fields[name]
+			if !ok {
+				return nil, false
+			}
+			field, err := v.FieldByIndexErr(index)
+			if err != nil {
+				// The field is promoted through a nil embedded pointer.
+				return nil, false
+			}
+			return field.Interface(), true
+		}, nil
+	}
+	return nil, fmt.Errorf("build: can't read parameters from %T, must be a map with string keys or a struct", values)
+}
+
+// paramFieldsCache maps struct types to the result of paramFields.
+var paramFieldsCache sync.Map
+
+// paramFields returns the index of the struct field holding each named
+// parameter of the struct type t. A field holds the parameter named by its
+// param tag, or by its name if it has no tag. The fields of embedded structs
+// without a tag are promoted, following the rules of Go: a shallower field
+// hides the deeper ones, and fields with the same name at the same depth hide
+// each other.
+func paramFields(t reflect.Type) map[string][]int {
+	if fields, ok := paramFieldsCache.Load(t); ok {
+		return fields.(map[string][]int)
+	}
+
+	type embedded struct {
+		t     reflect.Type
+		index []int
+	}
+	fields := make(map[string][]int)
+	visited := make(map[reflect.Type]bool)
+	for current := []embedded{{t: t}}; len(current) > 0; {
+		var next []embedded
+		count := make(map[string]int)
+		for _, e := range current {
+			if visited[e.t] {
+				continue
+			}
+			visited[e.t] = true
+			for i := 0; i < e.t.NumField(); i++ {
+				field := e.t.Field(i)
+				if !field.IsExported() {
+					continue
+				}
+				index := append(e.index[:len(e.index):len(e.index)], i)
+				name := field.Tag.Get("param")
+				if name == "-" {
+					continue
+				}
+				if name == "" && field.Anonymous {
+					ft := field.Type
+					if ft.Kind() == reflect.Ptr {
+						ft = ft.Elem()
+					}
+					if ft.Kind() == reflect.Struct {
+						next = append(next, embedded{t: ft, index: index})
+						continue
+					}
+				}
+				if name == "" {
+					name = field.Name
+				}
+				if _, ok := fields[name]; ok && count[name] == 0 {
+					// Hidden by a shallower field.
+					continue
+				}
+				count[name]++
+				fields[name] = index
+			}
+		}
+		for name, n := range count {
+			if n > 1 {
+				// Ambiguous, but still hides the deeper fields.
+				fields[name] = nil
+			}
+		}
+		current = next
+	}
+	for name, index := range fields {
+		if index == nil {
+			delete(fields, name)
+		}
+	}
+
+	paramFieldsCache.Store(t, fields)
+	return fields
+}
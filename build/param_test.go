@@ -0,0 +1,142 @@
+package build
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"testing"
+)
+
+func TestParam(t *testing.T) {
+	stmt := Select(Columns("id", "name")...).
+		From(Ident("users")).
+		Where(Ident("org_id").Equal(Param("org_id")).
+			And(Ident("status").Equal(Bind("active"))).
+			And(Ident("id").Equal(Any(Param("ids")))))
+	query, args := stmt.Build()
+	const expected = `SELECT "id", "name" FROM "users" WHERE "org_id" = $1 AND "status" = $2 AND "id" = ANY($3)`
+	assertf(t, query == expected, "expected %q, got %q", expected, query)
+
+	type values struct {
+		OrgID int64   `param:"org_id"`
+		IDs   []int64 `param:"ids"`
+	}
+	for _, tt := range []struct {
+		name   string
+		values interface{}
+		args   []interface{}
+	}{{
+		name:   "map",
+		values: map[string]interface{}{"org_id": 1, "ids": []int64{2, 3}},
+		args:   []interface{}{1, "active", []int64{2, 3}},
+	}, {
+		name:   "typed map",
+		values: map[string]int{"org_id": 1, "ids": 2},
+		args:   []interface{}{1, "active", 2},
+	}, {
+		name:   "struct",
+		values: values{OrgID: 1, IDs: []int64{2, 3}},
+		args:   []interface{}{int64(1), "active", []int64{2, 3}},
+	}, {
+		name:   "pointer to struct",
+		values: &values{OrgID: 4},
+		args:   []interface{}{int64(4), "active", []int64(nil)},
+	}, {
+		name: "struct field names",
+		values: struct {
+			org_id int
+			ids    int
+		}{},
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			bound, err := BindParams(args, tt.values)
+			if tt.args == nil {
+				assertf(t, err != nil, "expected an error")
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertf(t, reflect.DeepEqual(bound, tt.args), "expected %#v, got %#v", tt.args, bound)
+		})
+	}
+
+	_, err := BindParams(args, map[string]interface{}{"org_id": 1})
+	assertf(t, err != nil, "expected an error for a missing parameter")
+	_, err = BindParams(args, 42)
+	assertf(t, err != nil, "expected an error for invalid values")
+	_, isparam := args[0].(namedParam)
+	assertf(t, isparam, "expected args to be unchanged")
+}
+
+func TestParamStructFields(t *testing.T) {
+	// The embedded types are exported, so that their fields are promoted.
+	type ParamOrg struct {
+		OrgID int64 `param:"org_id"`
+		IDs   int64
+	}
+	type ParamIDs struct {
+		IDs []int64 `param:"ids"`
+	}
+	type ParamOrgID struct {
+		OrgID int64 `param:"org_id"`
+	}
+	_, args := Select(Star).From(Ident("users")).
+		Where(Ident("org_id").Equal(Param("org_id")).And(Ident("id").Equal(Any(Param("ids"))))).
+		Build()
+	for _, tt := range []struct {
+		name   string
+		values interface{}
+		args   []interface{}
+	}{{
+		name: "embedded",
+		values: struct {
+			ParamOrg
+			IDs []int64 `param:"ids"`
+		}{ParamOrg{OrgID: 1, IDs: 2}, []int64{3}},
+		args: []interface{}{int64(1), []int64{3}},
+	}, {
+		name: "embedded pointer",
+		values: struct {
+			*ParamIDs
+			OrgID int64 `param:"org_id"`
+		}{&ParamIDs{IDs: []int64{3}}, 1},
+		args: []interface{}{int64(1), []int64{3}},
+	}, {
+		name: "nil embedded pointer",
+		values: struct {
+			*ParamIDs
+			OrgID int64 `param:"org_id"`
+		}{OrgID: 1},
+	}, {
+		name: "ambiguous",
+		values: struct {
+			ParamOrg
+			ParamOrgID
+			ParamIDs
+		}{},
+	}, {
+		name: "tagged field name",
+		values: struct {
+			OrgID int64 `param:"org"`
+			IDs   int64 `param:"ids"`
+		}{},
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			bound, err := BindParams(args, tt.values)
+			if tt.args == nil {
+				assertf(t, err != nil, "expected an error")
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertf(t, reflect.DeepEqual(bound, tt.args), "expected %#v, got %#v", tt.args, bound)
+		})
+	}
+}
+
+func TestParamNotBound(t *testing.T) {
+	_, args := Select(Star).From(Ident("users")).Where(Ident("id").Equal(Param("id"))).Build()
+	_, err := driver.DefaultParameterConverter.ConvertValue(args[0])
+	assertf(t, err != nil && err.Error() == `build: parameter "id" is not bound`, "expected an error, got %v", err)
+}
@@ -0,0 +1,140 @@
+package pgjson
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"strings"
+
+	"github.com/yansal/sql/build"
+)
+
+// Get returns the expression expr -> key, which gets the field key of the
+// JSON object expr. key is bound as a parameter.
+func Get(expr *build.InfixExpr, key string) *build.InfixExpr {
+	return expr.Op("->", build.Bind(key))
+}
+
+// GetText returns the expression expr ->> key, which gets the field key of
+// the JSON object expr as text. key is bound as a parameter.
+func GetText(expr *build.InfixExpr, key string) *build.InfixExpr {
+	return expr.Op("->>", build.Bind(key))
+}
+
+// Index returns the expression expr -> index, which gets the element index
+// of the JSON array expr.
+func Index(expr *build.InfixExpr, index int) *build.InfixExpr {
+	return expr.Op("->", build.Int(index))
+}
+
+// IndexText returns the expression expr ->> index, which gets the element
+// index of the JSON array expr as text.
+func IndexText(expr *build.InfixExpr, index int) *build.InfixExpr {
+	return expr.Op("->>", build.Int(index))
+}
+
+// GetPath returns the expression expr #> path, which gets the JSON object at
+// path. The elements of path are bound as parameters.
+func GetPath(expr *build.InfixExpr, path ...string) *build.InfixExpr {
+	return expr.Op("#>", array(path))
+}
+
+// GetPathText returns the expression expr #>> path, which gets the JSON
+// object at path as text. The elements of path are bound as parameters.
+func GetPathText(expr *build.InfixExpr, path ...string) *build.InfixExpr {
+	return expr.Op("#>>", array(path))
+}
+
+// Contains returns the expression expr @> value, which is true if the JSON
+// document expr contains value. value is marshaled to JSON and bound as a
+// parameter.
+func Contains(expr *build.InfixExpr, value interface{}) *build.InfixExpr {
+	return expr.Op("@>", Value(value))
+}
+
+// ContainedBy returns the expression expr <@ value, which is true if the JSON
+// document expr is contained in value. value is marshaled to JSON and bound
+// as a parameter.
+func ContainedBy(expr *build.InfixExpr, value interface{}) *build.InfixExpr {
+	return expr.Op("<@", Value(value))
+}
+
+// HasKey returns the expression expr ? key, which is true if key is a top
+// level key of the JSON object expr. key is bound as a parameter.
+func HasKey(expr *build.InfixExpr, key string) *build.InfixExpr {
+	return expr.Op("?", build.Bind(key))
+}
+
+// HasAnyKey returns the expression expr ?| keys, which is true if any of keys
+// is a top level key of the JSON object expr. keys are bound as parameters.
+func HasAnyKey(expr *build.InfixExpr, keys ...string) *build.InfixExpr {
+	return expr.Op("?|", array(keys))
+}
+
+// HasAllKeys returns the expression expr ?& keys, which is true if all of
+// keys are top level keys of the JSON object expr. keys are bound as
+// parameters.
+func HasAllKeys(expr *build.InfixExpr, keys ...string) *build.InfixExpr {
+	return expr.Op("?&", array(keys))
+}
+
+// array returns a text array of elems, with each element bound as a
+// parameter.
+func array(elems []string) *build.InfixExpr {
+	if len(elems) == 0 {
+		return build.Raw("ARRAY[]::text[]")
+	}
+	args := make([]interface{}, len(elems))
+	for i := range elems {
+		args[i] = elems[i]
+	}
+	return build.RawArgs("ARRAY["+strings.Repeat(", ?", len(elems))[2:]+"]", args...)
+}
+
+// Value returns value bound as a parameter, marshaled to JSON when the
+// statement is executed.
+func Value(value interface{}) *build.InfixExpr {
+	return build.Bind(jsonValue{value: value})
+}
+
+type jsonValue struct{ value interface{} }
+
+func (v jsonValue) Value() (driver.Value, error) {
+	b, err := json.Marshal(v.value)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// A Field is a key and a value of a JSON object built with BuildObject.
+type Field struct {
+	Key   string
+	Value build.Expression
+}
+
+// BuildObject returns a call to jsonb_build_object, which builds a JSON
+// object from fields. The keys are bound as parameters.
+func BuildObject(fields ...Field) *build.InfixExpr {
+	args := make([]build.Expression, 0, 2*len(fields))
+	for i := range fields {
+		args = append(args,
+			// jsonb_build_object takes arguments of any type, so the type
+			// of the parameter must be given explicitly.
+			build.RawArgs("?::text", fields[i].Key),
+			fields[i].Value,
+		)
+	}
+	return build.CallExpr("jsonb_build_object", args...)
+}
+
+// Agg returns the jsonb_agg aggregate, which aggregates expr as a JSON
+// array.
+func Agg(expr build.Expression) build.AggrExpression {
+	return build.Aggr("jsonb_agg", expr)
+}
+
+// ObjectAgg returns the jsonb_object_agg aggregate, which aggregates key and
+// value pairs as a JSON object.
+func ObjectAgg(key, value build.Expression) build.AggrExpression {
+	return build.Aggr("jsonb_object_agg", key, value)
+}
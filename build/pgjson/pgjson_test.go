@@ -0,0 +1,75 @@
+package pgjson
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/yansal/sql/build"
+)
+
+func TestPGJSON(t *testing.T) {
+	data := build.Ident("data")
+	for _, tt := range []struct {
+		stmt *build.SelectStmt
+		out  string
+		args []interface{}
+	}{{
+		stmt: build.Select(GetText(Get(data, "user"), "name")).From(build.Ident("events")),
+		out:  `SELECT "data" -> $1 ->> $2 FROM "events"`,
+		args: []interface{}{"user", "name"},
+	}, {
+		stmt: build.Select(IndexText(Index(data, 0), 1)).From(build.Ident("events")),
+		out:  `SELECT "data" -> 0 ->> 1 FROM "events"`,
+	}, {
+		stmt: build.Select(GetPath(data, "a", "b"), GetPathText(data)).From(build.Ident("events")),
+		out:  `SELECT "data" #> ARRAY[$1, $2], "data" #>> (ARRAY[]::text[]) FROM "events"`,
+		args: []interface{}{"a", "b"},
+	}, {
+		stmt: build.Select(build.Star).From(build.Ident("events")).
+			Where(HasKey(data, "user").
+				And(HasAnyKey(data, "a", "b")).
+				And(HasAllKeys(data, "c")).
+				And(GetText(data, "kind").Equal(build.Bind("click")))),
+		out:  `SELECT * FROM "events" WHERE "data" ? $1 AND "data" ?| ARRAY[$2, $3] AND "data" ?& ARRAY[$4] AND "data" ->> $5 = $6`,
+		args: []interface{}{"user", "a", "b", "c", "kind", "click"},
+	}, {
+		stmt: build.Select(BuildObject(
+			Field{Key: "id", Value: build.Ident("id")},
+			Field{Key: "tags", Value: Agg(build.Ident("tag")).OrderBy(build.Ident("tag"))},
+		), ObjectAgg(build.Ident("key"), build.Ident("value"))).From(build.Ident("tags")).GroupBy(build.Ident("id")),
+		out:  `SELECT jsonb_build_object($1::text, "id", $2::text, jsonb_agg("tag" ORDER BY "tag")), jsonb_object_agg("key", "value") FROM "tags" GROUP BY "id"`,
+		args: []interface{}{"id", "tags"},
+	}} {
+		t.Run(tt.out, func(t *testing.T) {
+			out, args := tt.stmt.Build()
+			assertf(t, out == tt.out, "expected %q, got %q", tt.out, out)
+			assertf(t, reflect.DeepEqual(args, tt.args), "expected %#v, got %#v", tt.args, args)
+		})
+	}
+}
+
+func TestContains(t *testing.T) {
+	stmt := build.Select(build.Star).From(build.Ident("events")).
+		Where(Contains(build.Ident("data"), map[string]interface{}{"kind": "click"}).
+			Or(ContainedBy(build.Ident("data"), []int{1, 2})))
+	out, args := stmt.Build()
+	expected := `SELECT * FROM "events" WHERE "data" @> $1 OR "data" <@ $2`
+	assertf(t, out == expected, "expected %q, got %q", expected, out)
+	assertf(t, len(args) == 2, "expected 2 args, got %d", len(args))
+
+	for i, expected := range []string{`{"kind":"click"}`, `[1,2]`} {
+		v, err := args[i].(jsonValue).Value()
+		assertf(t, err == nil, "expected no error, got %v", err)
+		assertf(t, v == expected, "expected %q, got %q", expected, v)
+	}
+
+	_, err := jsonValue{value: func() {}}.Value()
+	assertf(t, err != nil, "expected an error")
+}
+
+func assertf(t *testing.T, ok bool, msg string, args ...interface{}) {
+	t.Helper()
+	if !ok {
+		t.Errorf(msg, args...)
+	}
+}
@@ -0,0 +1,173 @@
+package build
+
+// Operator precedence levels, from lowest to highest, as documented in
+// https://www.postgresql.org/docs/current/sql-syntax-lexical.html#SQL-PRECEDENCE.
+// precRaw is the precedence of raw SQL fragments, whose top operator is
+// unknown.
+const (
+	precRaw = iota
+	precOr
+	precAnd
+	precNot
+	precIs
+	precComparison
+	precLike
+	precOther
+	precAdd
+	precMul
+	precExp
+	precAt
+	precCollate
+	precCast
+	precAtom
+)
+
+var precedences = map[string]int{
+	"OR":                   precOr,
+	"AND":                  precAnd,
+	"NOT":                  precNot,
+	"IS NULL":              precIs,
+	"IS NOT NULL":          precIs,
+	"IS DISTINCT FROM":     precIs,
+	"IS NOT DISTINCT FROM": precIs,
+	"=":                    precComparison,
+	"!=":                   precComparison,
+	"<>":                   precComparison,
+	"<":                    precComparison,
+	">":                    precComparison,
+	"<=":                   precComparison,
+	">=":                   precComparison,
+	"BETWEEN":              precLike,
+	"NOT BETWEEN":          precLike,
+	"IN":                   precLike,
+	"NOT IN":               precLike,
+	"LIKE":                 precLike,
+	"NOT LIKE":             precLike,
+	"ILIKE":                precLike,
+	"NOT ILIKE":            precLike,
+	"SIMILAR TO":           precLike,
+	"NOT SIMILAR TO":       precLike,
+	"+":                    precAdd,
+	"-":                    precAdd,
+	"*":                    precMul,
+	"/":                    precMul,
+	"%":                    precMul,
+	"^":                    precExp,
+	"AT TIME ZONE":         precAt,
+	"AT LOCAL":             precAt,
+	"COLLATE":              precCollate,
+	"::":                   precCast,
+}
+
+// opPrecedence returns the precedence of the operator op. Unknown operators
+// have the precedence of any other operator.
+func opPrecedence(op string) int {
+	if p, ok := precedences[op]; ok {
+		return p
+	}
+	return precOther
+}
+
+// precedence returns the precedence of the top operator of expr, or precAtom
+// if expr has no top operator.
+func precedence(expr Expression) int {
+	switch e := expr.(type) {
+	case *InfixExpr:
+		if e.op == "" {
+			if e.left == nil {
+				return precAtom
+			}
+			return precedence(e.left)
+		}
+		return opPrecedence(e.op)
+	case *betweenExpr:
+		return precLike
+	case raw:
+		return rawPrecedence(string(e), false)
+	case *rawArgs:
+		return rawPrecedence(e.s, true)
+	}
+	return precAtom
+}
+
+// rawPrecedence returns precAtom if the raw SQL fragment s is a single term,
+// like a name, a literal or a function call, and precRaw otherwise. If
+// markers is true, ? is a parameter marker.
+func rawPrecedence(s string, markers bool) int {
+	if s == "" || s == "*" {
+		return precAtom
+	}
+	var (
+		depth int
+		quote byte
+	)
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '(' || c == '[':
+			depth++
+		case c == ')' || c == ']':
+			depth--
+		case depth > 0:
+		case c == '?' && markers && (i+1 == len(s) || s[i+1] != '?'):
+		case c == '_' || c == '.' || c == '$' || c >= 0x80,
+			'0' <= c && c <= '9', 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z':
+		case c == '*' && i > 0 && s[i-1] == '.':
+		default:
+			return precRaw
+		}
+	}
+	return precAtom
+}
+
+// isLeftAssociative reports whether operators with the precedence p are left
+// associative. Other operators don't associate, e.g. a = b = c is invalid.
+func isLeftAssociative(p int) bool {
+	switch p {
+	case precIs, precComparison, precLike:
+		return false
+	}
+	return true
+}
+
+// isAssociative reports whether a op (b op c) is equivalent to a op b op c,
+// where right is (b op c).
+func isAssociative(op string, right Expression) bool {
+	switch op {
+	case "AND", "OR", "||":
+	default:
+		return false
+	}
+	for {
+		i, ok := right.(*InfixExpr)
+		if !ok {
+			return false
+		}
+		if i.op != "" {
+			return i.op == op
+		}
+		right = i.left
+	}
+}
+
+// buildOperand builds the operand expr, with parentheses if paren is true or
+// if expr is a subquery.
+func buildOperand(b *builder, expr Expression, paren bool) {
+	if isQuery(expr) {
+		b.subquery(expr)
+		return
+	}
+	if paren {
+		b.write("(")
+		expr.build(b)
+		b.write(")")
+		return
+	}
+	expr.build(b)
+}
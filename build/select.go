@@ -1,12 +1,16 @@
 package build
 
-import "fmt"
-
 // Select returns a new SELECT statement.
 func Select(exprs ...Expression) *SelectStmt {
 	return &SelectStmt{exprs: exprs}
 }
 
+// Distinct adds the DISTINCT keyword.
+func (s *SelectStmt) Distinct() *SelectStmt {
+	s.distinct = true
+	return s
+}
+
 // DistinctOn adds a DISINCT ON clause.
 func (s *SelectStmt) DistinctOn(exprs ...Expression) *SelectStmt {
 	s.distincton = exprs
@@ -25,13 +29,44 @@ func (s *SelectStmt) Where(condition Expression) *SelectStmt {
 	return s
 }
 
+// AndWhere adds condition to the WHERE clause with the AND operator. If there
+// is no WHERE clause, AndWhere adds one. If condition is nil, AndWhere does
+// nothing.
+func (s *SelectStmt) AndWhere(condition Expression) *SelectStmt {
+	s.where = s.where.and("AND", condition)
+	return s
+}
+
+// OrWhere adds condition to the WHERE clause with the OR operator. If there
+// is no WHERE clause, OrWhere adds one. If condition is nil, OrWhere does
+// nothing.
+func (s *SelectStmt) OrWhere(condition Expression) *SelectStmt {
+	s.where = s.where.and("OR", condition)
+	return s
+}
+
 // GroupBy adds a GROUP BY clause.
 func (s *SelectStmt) GroupBy(elements ...Expression) *SelectStmt {
 	s.groupby = elements
 	return s
 }
 
+// Having adds a HAVING clause.
+func (s *SelectStmt) Having(condition Expression) *SelectStmt {
+	s.having = &having{Expression: condition}
+	return s
+}
+
+// Window adds a named window to the WINDOW clause.
+func (s *SelectStmt) Window(name string, def WindowDefinition) *SelectStmt {
+	s.windows = append(s.windows, namedWindow{name: identifier(name), def: def})
+	return s
+}
+
 // Union adds a UNION clause.
+//
+// Deprecated: Use the Union function, which parenthesizes each statement and
+// lets ORDER BY, LIMIT and OFFSET apply to the whole compound statement.
 func (s *SelectStmt) Union(stmt Expression) *SelectStmt {
 	s.unions = append(s.unions, stmt)
 	return s
@@ -55,11 +90,27 @@ func (s *SelectStmt) Offset(start Expression) *SelectStmt {
 	return s
 }
 
-// Build builds s and its parameters.
+// Build builds s and its parameters. Build panics if s is invalid.
 func (s *SelectStmt) Build() (string, []interface{}) {
-	b := new(builder)
-	s.build(b)
-	return b.buf.String(), b.params
+	return mustBuildStmt(s, Postgres)
+}
+
+// BuildFor builds s and its parameters for the dialect d. BuildFor panics
+// if s is invalid.
+func (s *SelectStmt) BuildFor(d Dialect) (string, []interface{}) {
+	return mustBuildStmt(s, d)
+}
+
+// BuildErr builds s and its parameters. If s is invalid, BuildErr
+// returns a *BuildError.
+func (s *SelectStmt) BuildErr() (string, []interface{}, error) {
+	return buildStmt(s, Postgres)
+}
+
+// BuildErrFor builds s and its parameters for the dialect d. If s is
+// invalid, BuildErrFor returns a *BuildError.
+func (s *SelectStmt) BuildErrFor(d Dialect) (string, []interface{}, error) {
+	return buildStmt(s, d)
 }
 
 func (s *SelectStmt) build(b *builder) {
@@ -69,6 +120,12 @@ func (s *SelectStmt) build(b *builder) {
 
 	b.write("SELECT ")
 
+	if s.distinct {
+		if s.distincton != nil {
+			b.errorf("SELECT statement has both DISTINCT and DISTINCT ON")
+		}
+		b.write("DISTINCT ")
+	}
 	if s.distincton != nil {
 		s.distincton.build(b)
 	}
@@ -76,53 +133,64 @@ func (s *SelectStmt) build(b *builder) {
 	s.exprs.build(b)
 
 	if s.from != nil {
-		b.write(" ")
+		b.clause()
 		s.from.build(b)
 	}
 
 	if s.where != nil {
-		b.write(" ")
+		b.clause()
 		s.where.build(b)
 	}
 
 	if s.groupby != nil {
-		b.write(" ")
+		b.clause()
 		s.groupby.build(b)
 	}
 
+	if s.having != nil {
+		b.clause()
+		s.having.build(b)
+	}
+
+	if s.windows != nil {
+		b.clause()
+		s.windows.build(b)
+	}
+
 	if s.unions != nil {
-		b.write(" ")
+		b.clause()
 		s.unions.build(b)
 	}
 
 	if s.orderby != nil {
-		b.write(" ")
+		b.clause()
 		s.orderby.build(b)
 	}
 
-	if s.limit != nil {
-		b.write(" ")
-		s.limit.build(b)
-	}
+	buildLimitOffset(b, s.orderby != nil, s.limit, s.offset)
 
-	if s.offset != nil {
-		b.write(" ")
-		s.offset.build(b)
+	if s.locking != nil {
+		b.clause()
+		s.locking.build(b)
 	}
 }
 
 // A SelectStmt is a SELECT statement.
 type SelectStmt struct {
 	ctes       *CTEs
+	distinct   bool
 	distincton distincton
 	exprs      selectexprs
 	from       from
 	where      *where
 	groupby    groupby
+	having     *having
+	windows    windows
 	unions     unions
 	orderby    orderby
 	limit      *limit
 	offset     *offset
+	locking    locking
 }
 
 type selectexprs []Expression
@@ -132,7 +200,7 @@ func (exprs selectexprs) build(b *builder) {
 		if i > 0 {
 			b.write(", ")
 		}
-		exprs[i].build(b)
+		buildOperand(b, exprs[i], false)
 	}
 }
 
@@ -155,10 +223,8 @@ func (e asExpr) As(alias string) Expression {
 }
 
 func (e asExpr) build(b *builder) {
-	if _, ok := e.expr.(*SelectStmt); ok {
-		b.write("(")
-		e.expr.build(b)
-		b.write(")")
+	if isQuery(e.expr) {
+		b.subquery(e.expr)
 	} else {
 		e.expr.build(b)
 	}
@@ -197,7 +263,7 @@ func (f from) build(b *builder) {
 		if i > 0 {
 			b.write(", ")
 		}
-		f[i].build(b)
+		buildFromItem(b, f[i])
 	}
 }
 
@@ -207,9 +273,25 @@ func FromExpr(expr Expression) AsExpr {
 
 type where struct{ Expression }
 
+// and returns w with condition appended with op. If w is nil, and returns a
+// new where with condition. If condition is nil, and returns w.
+func (w *where) and(op string, condition Expression) *where {
+	if isNil(condition) {
+		return w
+	}
+	if w == nil {
+		return &where{Expression: condition}
+	}
+	return &where{Expression: join(op, []Expression{w.Expression, condition})}
+}
+
 func (w where) build(b *builder) {
+	if isNil(w.Expression) {
+		b.errorf("WHERE clause has a nil condition")
+		return
+	}
 	b.write("WHERE ")
-	w.Expression.build(b)
+	buildOperand(b, w.Expression, false)
 }
 
 type groupby []Expression
@@ -224,6 +306,90 @@ func (g groupby) build(b *builder) {
 	}
 }
 
+// GroupingSets returns a GROUPING SETS grouping element. Use GroupingSet to
+// group several expressions in a single set.
+func GroupingSets(sets ...Expression) Expression {
+	return groupingElement{name: "GROUPING SETS", exprs: sets}
+}
+
+// Rollup returns a ROLLUP grouping element.
+func Rollup(exprs ...Expression) Expression {
+	return groupingElement{name: "ROLLUP", exprs: exprs}
+}
+
+// Cube returns a CUBE grouping element.
+func Cube(exprs ...Expression) Expression {
+	return groupingElement{name: "CUBE", exprs: exprs}
+}
+
+// GroupingSet returns a parenthesized list of grouping expressions, for use in
+// GroupingSets, Rollup and Cube. An empty GroupingSet is the empty grouping
+// set.
+func GroupingSet(exprs ...Expression) Expression {
+	return groupingSet(exprs)
+}
+
+type groupingElement struct {
+	name  string
+	exprs []Expression
+}
+
+func (g groupingElement) build(b *builder) {
+	b.write(g.name)
+	b.write(" (")
+	for i := range g.exprs {
+		if i > 0 {
+			b.write(", ")
+		}
+		g.exprs[i].build(b)
+	}
+	b.write(")")
+}
+
+type groupingSet []Expression
+
+func (g groupingSet) build(b *builder) {
+	b.write("(")
+	for i := range g {
+		if i > 0 {
+			b.write(", ")
+		}
+		g[i].build(b)
+	}
+	b.write(")")
+}
+
+type having struct{ Expression }
+
+func (h having) build(b *builder) {
+	if isNil(h.Expression) {
+		b.errorf("HAVING clause has a nil condition")
+		return
+	}
+	b.write("HAVING ")
+	buildOperand(b, h.Expression, false)
+}
+
+type namedWindow struct {
+	name identifier
+	def  WindowDefinition
+}
+
+type windows []namedWindow
+
+func (w windows) build(b *builder) {
+	b.write("WINDOW ")
+	for i := range w {
+		if i > 0 {
+			b.write(", ")
+		}
+		w[i].name.build(b)
+		b.write(" AS ( ")
+		w[i].def.build(b)
+		b.write(" )")
+	}
+}
+
 type unions []Expression
 
 func (u unions) build(b *builder) {
@@ -248,6 +414,46 @@ func (o orderby) build(b *builder) {
 	}
 }
 
+// buildLimitOffset builds the LIMIT and OFFSET clauses with the syntax of the
+// dialect of b. The OFFSET FETCH syntax requires an ORDER BY clause, so if the
+// statement is not ordered, an ORDER BY clause with no effect is added.
+func buildLimitOffset(b *builder, ordered bool, limit *limit, offset *offset) {
+	switch b.dialect.LimitSyntax() {
+	case OffsetFetch:
+		if limit == nil && offset == nil {
+			break
+		}
+		if !ordered {
+			b.clause()
+			b.write("ORDER BY (SELECT NULL)")
+		}
+		b.clause()
+		b.write("OFFSET ")
+		if offset != nil {
+			offset.Expression.build(b)
+		} else {
+			b.write("0")
+		}
+		b.write(" ROWS")
+		if limit != nil {
+			b.clause()
+			b.write("FETCH NEXT ")
+			limit.Expression.build(b)
+			b.write(" ROWS ONLY")
+		}
+	default:
+		if limit != nil {
+			b.clause()
+			limit.build(b)
+		}
+
+		if offset != nil {
+			b.clause()
+			offset.build(b)
+		}
+	}
+}
+
 type limit struct{ Expression }
 
 func (l limit) build(b *builder) {
@@ -285,7 +491,7 @@ func (o orderExpr) Nulls(nulls Nulls) Expression {
 }
 
 func (o orderExpr) build(b *builder) {
-	o.expr.build(b)
+	buildOperand(b, o.expr, false)
 
 	if o.direction != nil {
 		switch d := *o.direction; d {
@@ -294,7 +500,7 @@ func (o orderExpr) build(b *builder) {
 		case Desc:
 			b.write(" DESC")
 		default:
-			panic(fmt.Sprintf("unknown direction %d", d))
+			b.errorf("unknown direction %d", d)
 		}
 	}
 
@@ -305,7 +511,7 @@ func (o orderExpr) build(b *builder) {
 		case Last:
 			b.write(" NULLS LAST")
 		default:
-			panic(fmt.Sprintf("unknown nulls %d", n))
+			b.errorf("unknown nulls %d", n)
 		}
 	}
 }
@@ -40,6 +40,42 @@ func TestSelect(t *testing.T) {
 	}, {
 		stmt: Select(CallExpr("count", Star), Ident("foo")).From(Ident("bar")).GroupBy(Ident("foo")),
 		out:  `SELECT count(*), "foo" FROM "bar" GROUP BY "foo"`,
+	}, {
+		stmt: Select(Ident("foo"), CallExpr("sum", Ident("amount"))).
+			From(Ident("bar")).
+			GroupBy(Ident("foo")).
+			Having(CallExpr("sum", Ident("amount")).GreaterThan(Bind(100))),
+		out:  `SELECT "foo", sum("amount") FROM "bar" GROUP BY "foo" HAVING sum("amount") > $1`,
+		args: []interface{}{100},
+	}, {
+		stmt: Select(Ident("brand"), Ident("size"), CallExpr("sum", Ident("sales"))).
+			From(Ident("items_sold")).
+			GroupBy(GroupingSets(Ident("brand"), Ident("size"), GroupingSet())),
+		out: `SELECT "brand", "size", sum("sales") FROM "items_sold" GROUP BY GROUPING SETS ("brand", "size", ())`,
+	}, {
+		stmt: Select(Ident("brand"), Ident("size"), CallExpr("sum", Ident("sales"))).
+			From(Ident("items_sold")).
+			GroupBy(Ident("a"), Rollup(Ident("brand"), GroupingSet(Ident("size"), Ident("color")))),
+		out: `SELECT "brand", "size", sum("sales") FROM "items_sold" GROUP BY "a", ROLLUP ("brand", ("size", "color"))`,
+	}, {
+		stmt: Select(Ident("brand"), Ident("size"), CallExpr("sum", Ident("sales"))).
+			From(Ident("items_sold")).
+			GroupBy(Cube(Ident("brand"), Ident("size"))).
+			Having(CallExpr("grouping", Ident("brand")).Equal(Int(0))),
+		out: `SELECT "brand", "size", sum("sales") FROM "items_sold" GROUP BY CUBE ("brand", "size") HAVING grouping("brand") = 0`,
+	}, {
+		stmt: Select(Star).From(Ident("users")).
+			AndWhere(Ident("name").Equal(Bind("foo"))).
+			AndWhere(nil).
+			AndWhere(Ident("age").GreaterThan(Bind(18))).
+			OrWhere(Ident("admin")),
+		out:  `SELECT * FROM "users" WHERE "name" = $1 AND "age" > $2 OR "admin"`,
+		args: []interface{}{"foo", 18},
+	}, {
+		stmt: Select(Star).From(Ident("users")).
+			Where(Ident("a").Or(Ident("b"))).
+			AndWhere(Ident("c")),
+		out: `SELECT * FROM "users" WHERE ("a" OR "b") AND "c"`,
 	}, {
 		stmt: Select(Columns("now")...).From(
 			FromExpr(Select(CallExpr("now"))).As("now")),
@@ -82,6 +118,9 @@ func TestSelect(t *testing.T) {
 			From(Ident("weather_reports")).
 			OrderBy(Ident("location"), Order(Ident("time"), Desc)),
 		out: `SELECT DISTINCT ON ("location") "location", "time", "report" FROM "weather_reports" ORDER BY "location", "time" DESC`,
+	}, {
+		stmt: Select(Ident("location")).Distinct().From(Ident("weather_reports")),
+		out:  `SELECT DISTINCT "location" FROM "weather_reports"`,
 	}, {
 		stmt: Select(Ident("distributors.name")).
 			From(Ident("distributors")).
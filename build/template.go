@@ -0,0 +1,98 @@
+package build
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Compile builds stmt once and returns a reusable Template.
+func Compile(stmt Expression) (*Template, error) {
+	return CompileFor(stmt, Postgres)
+}
+
+// CompileFor builds stmt once for the dialect d and returns a reusable
+// Template.
+func CompileFor(stmt Expression, d Dialect) (*Template, error) {
+	b := &builder{dialect: d}
+	stmt.build(b)
+	if b.errs != nil {
+		return nil, &BuildError{Errs: b.errs}
+	}
+	return &Template{query: b.buf.String(), params: b.params, binds: b.binds}, nil
+}
+
+// A Template is a compiled statement. Its query is built once, and its
+// parameters are substituted with Args on each execution.
+type Template struct {
+	query  string
+	params []interface{}
+	binds  []bindSlot
+}
+
+// Query returns the query of t.
+func (t *Template) Query() string { return t.query }
+
+// Args returns the parameters of t, where the values bound with Bind and
+// BindArray are substituted with values, in the order they appear in the
+// query. Values are checked like with Bind: a value bound as a single
+// parameter must be bindable and not a list, and a value bound as a list of
+// parameters must be a slice with the same number of parameters, nested
+// lists included. The named parameters are left unchanged, and can be
+// replaced with BindParams.
+func (t *Template) Args(values ...interface{}) ([]interface{}, error) {
+	if len(values) != len(t.binds) {
+		return nil, fmt.Errorf("build: template has %d bound values, got %d", len(t.binds), len(values))
+	}
+	args := make([]interface{}, len(t.params))
+	copy(args, t.params)
+	for i, slot := range t.binds {
+		value := values[i]
+		switch {
+		case slot.array:
+			args[slot.start] = value
+		case slot.list:
+			if !isList(value) {
+				return nil, fmt.Errorf("build: bound value %d must be a slice, got %#v", i, value)
+			}
+			// The list is appended in place, and only reallocated if it
+			// has too many parameters.
+			list, err := appendList(args[slot.start:slot.start:slot.start+slot.n], value, slot.nested)
+			if err != nil {
+				return nil, err
+			}
+			if len(list) != slot.n {
+				return nil, fmt.Errorf("build: bound value %d must be a slice of %d parameters, got %#v", i, slot.n, value)
+			}
+		default:
+			if isList(value) || !isBindable(value) {
+				return nil, fmt.Errorf("build: bound value %d must be a single parameter, got %#v (%T)", i, value, value)
+			}
+			args[slot.start] = value
+		}
+	}
+	return args, nil
+}
+
+// appendList appends the elements of the list value to args. If nested is
+// true, nested lists are flattened like with Bind.
+func appendList(args []interface{}, value interface{}, nested bool) ([]interface{}, error) {
+	v := reflect.ValueOf(value)
+	if v.Len() == 0 {
+		return nil, fmt.Errorf("build: can't bind empty list %T", value)
+	}
+	for j := 0; j < v.Len(); j++ {
+		elem := v.Index(j).Interface()
+		switch {
+		case isList(elem) && nested:
+			var err error
+			if args, err = appendList(args, elem, nested); err != nil {
+				return nil, err
+			}
+		case !isList(elem) && isBindable(elem):
+			args = append(args, elem)
+		default:
+			return nil, fmt.Errorf("build: don't know how to bind value %#v (%T)", elem, elem)
+		}
+	}
+	return args, nil
+}
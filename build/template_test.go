@@ -0,0 +1,116 @@
+package build
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTemplate(t *testing.T) {
+	stmt := Select(Columns("id", "name")...).
+		From(Ident("users")).
+		Where(Ident("org_id").Equal(Bind(1)).
+			And(Ident("id").In(Bind([]int{2, 3}))).
+			And(Ident("name").Equal(Param("name"))).
+			And(Ident("tags").Op("&&", BindArray([]string{"a"})))).
+		Limit(Bind(10))
+	tmpl, err := Compile(stmt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected, _ := stmt.Build()
+	assertf(t, tmpl.Query() == expected, "expected %q, got %q", expected, tmpl.Query())
+
+	args, err := tmpl.Args(4, []int{5, 6}, []string{"b", "c"}, 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	args, err = BindParams(args, map[string]interface{}{"name": "foo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedargs := []interface{}{4, 5, 6, "foo", []string{"b", "c"}, 20}
+	assertf(t, reflect.DeepEqual(args, expectedargs), "expected %#v, got %#v", expectedargs, args)
+
+	_, err = tmpl.Args(4)
+	assertf(t, err != nil, "expected an error for a missing value")
+	_, err = tmpl.Args(4, []int{5}, []string{"b"}, 20)
+	assertf(t, err != nil, "expected an error for a list of a different length")
+
+	for _, values := range [][]interface{}{
+		{[]int{1, 2}, []int{5, 6}, []string{"b"}, 20},
+		{map[string]int{}, []int{5, 6}, []string{"b"}, 20},
+		{4, []int{5, 6}, []string{"b"}, struct{}{}},
+		{4, 5, []string{"b"}, 20},
+		{4, []interface{}{5, []int{6}}, []string{"b"}, 20},
+		{4, []interface{}{5, struct{}{}}, []string{"b"}, 20},
+		{4, []int{5, 6, 7}, []string{"b"}, 20},
+	} {
+		_, err = tmpl.Args(values...)
+		assertf(t, err != nil, "expected an error for %v", values)
+	}
+
+	_, err = Compile(Select(Bind(struct{}{})))
+	assertf(t, err != nil, "expected an error for an invalid statement")
+}
+
+func TestTemplateNestedList(t *testing.T) {
+	tmpl, err := Compile(Select(Star).From(Ident("t")).
+		Where(RawArgs("(a, b) IN ?", [][]int{{1, 2}, {3, 4}})))
+	if err != nil {
+		t.Fatal(err)
+	}
+	args, err := tmpl.Args([][]int{{5, 6}, {7, 8}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []interface{}{5, 6, 7, 8}
+	assertf(t, reflect.DeepEqual(args, expected), "expected %#v, got %#v", expected, args)
+
+	_, err = tmpl.Args([][]int{{5, 6}})
+	assertf(t, err != nil, "expected an error for a list with fewer parameters")
+	_, err = tmpl.Args([][]int{{5, 6}, {7, 8}, {9, 10}})
+	assertf(t, err != nil, "expected an error for a list with more parameters")
+}
+
+func benchmarkStmt() *SelectStmt {
+	return With("recent", Select(Ident("user_id")).
+		From(Ident("events")).
+		Where(Ident("created_at").GreaterThan(Bind("2020-01-01")))).
+		Select(Columns("users.id", "users.name", "users.email")...).
+		From(FromItem(Ident("users")).Join(Ident("recent")).On(Ident("recent.user_id").Equal(Ident("users.id")))).
+		Where(Ident("users.org_id").Equal(Bind(1)).And(Ident("users.status").In(Bind([]string{"active", "pending"})))).
+		OrderBy(Order(Ident("users.id"), Desc)).
+		Limit(Bind(20)).
+		Offset(Bind(40))
+}
+
+func BenchmarkBuild(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		benchmarkStmt().Build()
+	}
+}
+
+func BenchmarkBuildPrebuilt(b *testing.B) {
+	stmt := benchmarkStmt()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		stmt.Build()
+	}
+}
+
+func BenchmarkTemplateArgs(b *testing.B) {
+	tmpl, err := Compile(benchmarkStmt())
+	if err != nil {
+		b.Fatal(err)
+	}
+	statuses := []string{"active", "pending"}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := tmpl.Args("2020-01-01", 1, statuses, 20, 40); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -23,17 +23,49 @@ func (stmt *UpdateStmt) Where(condition Expression) *UpdateStmt {
 	return stmt
 }
 
+// AndWhere adds condition to the WHERE clause with the AND operator. If there
+// is no WHERE clause, AndWhere adds one. If condition is nil, AndWhere does
+// nothing.
+func (stmt *UpdateStmt) AndWhere(condition Expression) *UpdateStmt {
+	stmt.where = stmt.where.and("AND", condition)
+	return stmt
+}
+
+// OrWhere adds condition to the WHERE clause with the OR operator. If there
+// is no WHERE clause, OrWhere adds one. If condition is nil, OrWhere does
+// nothing.
+func (stmt *UpdateStmt) OrWhere(condition Expression) *UpdateStmt {
+	stmt.where = stmt.where.and("OR", condition)
+	return stmt
+}
+
 // Returning adds a RETURNING clause.
 func (stmt *UpdateStmt) Returning(exprs ...Expression) *UpdateStmt {
 	stmt.returning = exprs
 	return stmt
 }
 
-// Build builds stmt and its parameters.
+// Build builds stmt and its parameters. Build panics if stmt is invalid.
 func (stmt *UpdateStmt) Build() (string, []interface{}) {
-	b := new(builder)
-	stmt.build(b)
-	return b.buf.String(), b.params
+	return mustBuildStmt(stmt, Postgres)
+}
+
+// BuildFor builds stmt and its parameters for the dialect d. BuildFor panics
+// if stmt is invalid.
+func (stmt *UpdateStmt) BuildFor(d Dialect) (string, []interface{}) {
+	return mustBuildStmt(stmt, d)
+}
+
+// BuildErr builds stmt and its parameters. If stmt is invalid, BuildErr
+// returns a *BuildError.
+func (stmt *UpdateStmt) BuildErr() (string, []interface{}, error) {
+	return buildStmt(stmt, Postgres)
+}
+
+// BuildErrFor builds stmt and its parameters for the dialect d. If stmt is
+// invalid, BuildErrFor returns a *BuildError.
+func (stmt *UpdateStmt) BuildErrFor(d Dialect) (string, []interface{}, error) {
+	return buildStmt(stmt, d)
 }
 
 func (stmt *UpdateStmt) build(b *builder) {
@@ -43,28 +75,33 @@ func (stmt *UpdateStmt) build(b *builder) {
 
 	b.write("UPDATE ")
 	stmt.table.build(b)
-	b.write(" SET ")
+	b.clause()
+	b.write("SET ")
+	if len(stmt.assignments) == 0 {
+		b.errorf("UPDATE statement has no assignments")
+	}
 	for i := range stmt.assignments {
 		if i > 0 {
 			b.write(", ")
 		}
 		stmt.assignments[i].columnname.build(b)
 		b.write(" = ")
-		stmt.assignments[i].expr.build(b)
+		buildOperand(b, stmt.assignments[i].expr, false)
 	}
 
 	if stmt.from != nil {
-		b.write(" ")
+		b.clause()
 		stmt.from.build(b)
 	}
 
 	if stmt.where != nil {
-		b.write(" ")
+		b.clause()
 		stmt.where.build(b)
 	}
 
 	if stmt.returning != nil {
-		b.write(" RETURNING ")
+		b.clause()
+		b.write("RETURNING ")
 		stmt.returning.build(b)
 	}
 }
@@ -39,6 +39,23 @@ func TestUpdate(t *testing.T) {
 			Where(Ident("accounts.name").Equal(String("Acme Corporation")).
 				And(Ident("employees.id").Equal(Ident("accounts.sales_person")))),
 		out: `UPDATE "employees" SET "sales_count" = "sales_count" + 1 FROM "accounts" WHERE "accounts"."name" = 'Acme Corporation' AND "employees"."id" = "accounts"."sales_person"`,
+	}, {
+		stmt: Update("table").
+			Set(Assign("foo", Bind("hello"))).
+			AndWhere(Ident("id").Equal(Bind(1))).
+			AndWhere(Ident("deleted").IsNull()),
+		out:  `UPDATE "table" SET "foo" = $1 WHERE "id" = $2 AND "deleted" IS NULL`,
+		args: []interface{}{"hello", 1},
+	}, {
+		stmt: Update("users").
+			Set(Assign("name", Ident("v.name"))).
+			From(ValuesTable(
+				Values{Bind(1), Bind("foo")},
+				Values{Bind(2), Bind("bar")},
+			).As("v", "id", "name")).
+			Where(Ident("users.id").Equal(Ident("v.id"))),
+		out:  `UPDATE "users" SET "name" = "v"."name" FROM (VALUES ($1, $2), ($3, $4)) AS "v" ("id", "name") WHERE "users"."id" = "v"."id"`,
+		args: []interface{}{1, "foo", 2, "bar"},
 	}} {
 		t.Run(tt.out, func(t *testing.T) {
 			out, args := tt.stmt.Build()
@@ -0,0 +1,668 @@
+package build
+
+// Walk traverses expr in depth-first order: it calls fn(expr), then, if fn
+// returns true, walks each of the child expressions of expr. Statements are
+// walked into, including their Common Table Expressions, clauses and
+// subqueries. Walk does not copy expr.
+//
+// Expressions returned by the functions of this package, e.g. Ident or Bind,
+// wrap the underlying expression in an *InfixExpr, so fn is called twice for
+// them: once with the *InfixExpr, and once with the underlying expression.
+// The accessors, e.g. IdentName, accept both.
+func Walk(expr Expression, fn func(Expression) bool) {
+	var walk func(Expression)
+	walk = func(expr Expression) {
+		if isNil(expr) || !fn(expr) {
+			return
+		}
+		if n, ok := expr.(node); ok {
+			n.children(walk)
+		}
+	}
+	walk(expr)
+}
+
+// Rewrite returns a copy of expr, where each expression is replaced with the
+// result of fn. Rewrite rewrites the child expressions of an expression
+// before calling fn on the expression itself, so that fn is called on a copy
+// of the expression with its rewritten children, which fn may modify. expr is
+// not modified. Like Walk, Rewrite calls fn with both the *InfixExpr wrappers
+// and the expressions they wrap. Use Walk to inspect expr without copying it.
+func Rewrite(expr Expression, fn func(Expression) Expression) Expression {
+	if isNil(expr) {
+		return expr
+	}
+	if n, ok := expr.(node); ok {
+		expr = n.rewrite(func(child Expression) Expression {
+			return Rewrite(child, fn)
+		})
+	}
+	return fn(expr)
+}
+
+// A node is an expression with child expressions.
+type node interface {
+	// children calls f with each non-nil child expression of the node.
+	children(f func(Expression))
+	// rewrite returns a copy of the node, where each child expression is
+	// replaced with the result of f.
+	rewrite(f func(Expression) Expression) Expression
+}
+
+// IdentName returns the name of the identifier expr, as passed to Ident.
+func IdentName(expr Expression) (string, bool) {
+	i, ok := unwrap(expr).(identifier)
+	return string(i), ok
+}
+
+// BindValue returns the value bound by the expression expr, as passed to
+// Bind or BindArray. The values passed to RawArgs are walked as bound values.
+func BindValue(expr Expression) (interface{}, bool) {
+	switch e := unwrap(expr).(type) {
+	case *bind:
+		return e.value, true
+	case *bindArray:
+		return e.value, true
+	}
+	return nil, false
+}
+
+// StringValue returns the string of the literal expr, as passed to String.
+func StringValue(expr Expression) (string, bool) {
+	s, ok := unwrap(expr).(stringExpr)
+	return string(s), ok
+}
+
+// ParamName returns the name of the named parameter expr, as passed to Param.
+func ParamName(expr Expression) (string, bool) {
+	p, ok := unwrap(expr).(namedParam)
+	return string(p), ok
+}
+
+// OperatorParts returns the operator of expr and its operands. left is nil
+// for prefix operators, e.g. NOT, and right is nil for postfix operators, e.g.
+// IS NULL.
+func OperatorParts(expr Expression) (op string, left, right Expression, ok bool) {
+	i, ok := unwrap(expr).(*InfixExpr)
+	if !ok || i.op == "" {
+		return "", nil, nil, false
+	}
+	return i.op, i.left, i.right, true
+}
+
+// JoinParts returns the left and right FROM items of the join expr, and its
+// join condition, if any.
+func JoinParts(expr Expression) (left, right, on Expression, ok bool) {
+	j, ok := unwrap(expr).(*joinExpr)
+	if !ok {
+		return nil, nil, nil, false
+	}
+	return j.left, j.right, j.on, true
+}
+
+// AliasParts returns the aliased expression of expr, and its alias, as built
+// with ColumnExpr, FromExpr or Lateral.
+func AliasParts(expr Expression) (aliased Expression, alias string, ok bool) {
+	switch e := unwrap(expr).(type) {
+	case asExpr:
+		return e.expr, string(e.alias), true
+	case lateralExpr:
+		return e.expr, string(e.alias), true
+	case valuesAliasExpr:
+		return e.values, string(e.alias), true
+	}
+	return nil, "", false
+}
+
+// FromItems returns the FROM items of s.
+func (s *SelectStmt) FromItems() []Expression { return s.from }
+
+// WhereCondition returns the WHERE condition of s, or nil.
+func (s *SelectStmt) WhereCondition() Expression {
+	if s.where == nil {
+		return nil
+	}
+	return s.where.Expression
+}
+
+// Table returns the table of stmt.
+func (stmt *InsertStmt) Table() Expression { return stmt.table }
+
+// Table returns the table of stmt.
+func (stmt *UpdateStmt) Table() Expression { return stmt.table }
+
+// FromItems returns the FROM items of stmt.
+func (stmt *UpdateStmt) FromItems() []Expression { return stmt.from }
+
+// WhereCondition returns the WHERE condition of stmt, or nil.
+func (stmt *UpdateStmt) WhereCondition() Expression {
+	if stmt.where == nil {
+		return nil
+	}
+	return stmt.where.Expression
+}
+
+// Table returns the table of stmt.
+func (stmt *DeleteStmt) Table() Expression { return stmt.table }
+
+// UsingItems returns the USING items of stmt.
+func (stmt *DeleteStmt) UsingItems() []Expression { return stmt.using }
+
+// WhereCondition returns the WHERE condition of stmt, or nil.
+func (stmt *DeleteStmt) WhereCondition() Expression {
+	if stmt.where == nil {
+		return nil
+	}
+	return stmt.where.Expression
+}
+
+// Table returns the target table of stmt.
+func (stmt *MergeStmt) Table() Expression { return stmt.table }
+
+// UsingItem returns the data source of stmt, or nil.
+func (stmt *MergeStmt) UsingItem() Expression { return stmt.using }
+
+// unwrap returns the expression wrapped by expr, if expr is an *InfixExpr
+// without operator or a FROM item.
+func unwrap(expr Expression) Expression {
+	for {
+		switch e := expr.(type) {
+		case *InfixExpr:
+			if e == nil || e.op != "" || e.left == nil {
+				return expr
+			}
+			expr = e.left
+		case *fromItemExpr:
+			expr = e.expr
+		default:
+			return expr
+		}
+	}
+}
+
+func rewriteExpr(f func(Expression) Expression, expr Expression) Expression {
+	if isNil(expr) {
+		return expr
+	}
+	return f(expr)
+}
+
+func rewriteExprs[S ~[]Expression](f func(Expression) Expression, exprs S) S {
+	if exprs == nil {
+		return nil
+	}
+	rewritten := make(S, len(exprs))
+	for i := range exprs {
+		rewritten[i] = rewriteExpr(f, exprs[i])
+	}
+	return rewritten
+}
+
+func rewriteAssignments(f func(Expression) Expression, assignments []Assignment) []Assignment {
+	if assignments == nil {
+		return nil
+	}
+	rewritten := make([]Assignment, len(assignments))
+	for i := range assignments {
+		rewritten[i] = Assignment{
+			columnname: rewriteExpr(f, assignments[i].columnname),
+			expr:       rewriteExpr(f, assignments[i].expr),
+		}
+	}
+	return rewritten
+}
+
+func (e *CTEs) rewriteCTEs(f func(Expression) Expression) *CTEs {
+	if e == nil {
+		return nil
+	}
+	c := e.Clone()
+	for i := range c.ctes {
+		c.ctes[i].stmt = rewriteExpr(f, c.ctes[i].stmt)
+	}
+	return c
+}
+
+func (w *where) rewrite(f func(Expression) Expression) *where {
+	if w == nil {
+		return nil
+	}
+	return &where{Expression: rewriteExpr(f, w.Expression)}
+}
+
+func (h *having) rewrite(f func(Expression) Expression) *having {
+	if h == nil {
+		return nil
+	}
+	return &having{Expression: rewriteExpr(f, h.Expression)}
+}
+
+func (l *limit) rewrite(f func(Expression) Expression) *limit {
+	if l == nil {
+		return nil
+	}
+	return &limit{Expression: rewriteExpr(f, l.Expression)}
+}
+
+func (o *offset) rewrite(f func(Expression) Expression) *offset {
+	if o == nil {
+		return nil
+	}
+	return &offset{Expression: rewriteExpr(f, o.Expression)}
+}
+
+func (s *SelectStmt) rewrite(f func(Expression) Expression) Expression {
+	c := s.Clone()
+	c.ctes = s.ctes.rewriteCTEs(f)
+	c.distincton = rewriteExprs(f, s.distincton)
+	c.exprs = rewriteExprs(f, s.exprs)
+	c.from = rewriteExprs(f, s.from)
+	c.where = s.where.rewrite(f)
+	c.groupby = rewriteExprs(f, s.groupby)
+	c.having = s.having.rewrite(f)
+	for i := range c.windows {
+		c.windows[i].def = c.windows[i].def.rewriteDef(f)
+	}
+	c.unions = rewriteExprs(f, s.unions)
+	c.orderby = rewriteExprs(f, s.orderby)
+	c.limit = s.limit.rewrite(f)
+	c.offset = s.offset.rewrite(f)
+	for i := range c.locking {
+		c.locking[i].of = rewriteExprs(f, s.locking[i].of)
+	}
+	return c
+}
+
+func (c *CompoundStmt) rewrite(f func(Expression) Expression) Expression {
+	cc := c.Clone()
+	cc.ctes = c.ctes.rewriteCTEs(f)
+	cc.stmts = rewriteExprs(f, c.stmts)
+	cc.orderby = rewriteExprs(f, c.orderby)
+	cc.limit = c.limit.rewrite(f)
+	cc.offset = c.offset.rewrite(f)
+	return cc
+}
+
+func (stmt *InsertStmt) rewrite(f func(Expression) Expression) Expression {
+	c := stmt.Clone()
+	c.ctes = stmt.ctes.rewriteCTEs(f)
+	c.table = rewriteExpr(f, stmt.table)
+	c.columns = rewriteExprs(f, stmt.columns)
+	c.valueslist = rewriteExpr(f, stmt.valueslist)
+	if stmt.onconflict != nil {
+		c.onconflict = &onconflictexpr{
+			target: rewriteExpr(f, stmt.onconflict.target),
+			action: ConflictAction{
+				do:     stmt.onconflict.action.do,
+				values: rewriteAssignments(f, stmt.onconflict.action.values),
+				where:  rewriteExpr(f, stmt.onconflict.action.where),
+			},
+		}
+	}
+	c.returning = rewriteExprs(f, stmt.returning)
+	return c
+}
+
+func (stmt *UpdateStmt) rewrite(f func(Expression) Expression) Expression {
+	c := stmt.Clone()
+	c.ctes = stmt.ctes.rewriteCTEs(f)
+	c.table = rewriteExpr(f, stmt.table)
+	c.assignments = rewriteAssignments(f, stmt.assignments)
+	c.from = rewriteExprs(f, stmt.from)
+	c.where = stmt.where.rewrite(f)
+	c.returning = rewriteExprs(f, stmt.returning)
+	return c
+}
+
+func (stmt *DeleteStmt) rewrite(f func(Expression) Expression) Expression {
+	c := stmt.Clone()
+	c.ctes = stmt.ctes.rewriteCTEs(f)
+	c.table = rewriteExpr(f, stmt.table)
+	c.using = rewriteExprs(f, stmt.using)
+	c.where = stmt.where.rewrite(f)
+	c.returning = rewriteExprs(f, stmt.returning)
+	return c
+}
+
+func (stmt *MergeStmt) rewrite(f func(Expression) Expression) Expression {
+	c := stmt.Clone()
+	c.ctes = stmt.ctes.rewriteCTEs(f)
+	c.table = rewriteExpr(f, stmt.table)
+	c.using = rewriteExpr(f, stmt.using)
+	c.on = rewriteExpr(f, stmt.on)
+	for i, w := range stmt.whens {
+		c.whens[i] = MergeWhen{
+			matched:     w.matched,
+			condition:   rewriteExpr(f, w.condition),
+			action:      w.action,
+			assignments: rewriteAssignments(f, w.assignments),
+			columns:     rewriteExprs(f, w.columns),
+			values:      rewriteExprs(f, w.values),
+		}
+	}
+	return c
+}
+
+func (e valueslistexpr) rewrite(f func(Expression) Expression) Expression {
+	rows := make([]Values, len(e.valueslist))
+	for i := range e.valueslist {
+		rows[i] = rewriteExprs(f, e.valueslist[i])
+	}
+	return valueslistexpr{valueslist: rows}
+}
+
+func (e queryexpr) rewrite(f func(Expression) Expression) Expression {
+	return queryexpr{query: rewriteExpr(f, e.query)}
+}
+
+func (e ConflictTargetExpr) rewrite(f func(Expression) Expression) Expression {
+	return ConflictTargetExpr{exprs: rewriteExprs(f, e.exprs), where: rewriteExpr(f, e.where)}
+}
+
+func (v Values) rewrite(f func(Expression) Expression) Expression {
+	return rewriteExprs(f, v)
+}
+
+func (i *InfixExpr) rewrite(f func(Expression) Expression) Expression {
+	return &InfixExpr{left: rewriteExpr(f, i.left), op: i.op, right: rewriteExpr(f, i.right)}
+}
+
+func (e *keywordExpr) rewrite(f func(Expression) Expression) Expression {
+	return &keywordExpr{keyword: e.keyword, expr: rewriteExpr(f, e.expr)}
+}
+
+func (e *betweenExpr) rewrite(f func(Expression) Expression) Expression {
+	return &betweenExpr{
+		expr: rewriteExpr(f, e.expr),
+		not:  e.not,
+		low:  rewriteExpr(f, e.low),
+		high: rewriteExpr(f, e.high),
+	}
+}
+
+func (e callExpr) rewrite(f func(Expression) Expression) Expression {
+	return callExpr{function: e.function, args: rewriteExprs(f, e.args)}
+}
+
+func (p *parenExpr) rewrite(f func(Expression) Expression) Expression {
+	return &parenExpr{expr: rewriteExpr(f, p.expr)}
+}
+
+func (a aggrExpression) rewrite(f func(Expression) Expression) Expression {
+	a.exprs = rewriteExprs(f, a.exprs)
+	a.orderby = rewriteExpr(f, a.orderby)
+	a.filterwhere = rewriteExpr(f, a.filterwhere)
+	return a
+}
+
+func (c CaseExpr) rewrite(f func(Expression) Expression) Expression {
+	whens := make([]casewhen, len(c.whens))
+	for i := range c.whens {
+		whens[i] = casewhen{
+			condition: rewriteExpr(f, c.whens[i].condition),
+			result:    rewriteExpr(f, c.whens[i].result),
+		}
+	}
+	c.whens = whens
+	c.elseresult = rewriteExpr(f, c.elseresult)
+	return c
+}
+
+func (w WindowFunctionExpr) rewrite(f func(Expression) Expression) Expression {
+	w.args = rewriteExprs(f, w.args)
+	w.filterwhere = rewriteExpr(f, w.filterwhere)
+	if w.over != nil {
+		over := w.over.rewriteDef(f)
+		w.over = &over
+	}
+	return w
+}
+
+func (d WindowDefinition) rewriteDef(f func(Expression) Expression) WindowDefinition {
+	d.partitionby = rewriteExprs(f, d.partitionby)
+	d.orderby = rewriteExprs(f, d.orderby)
+	if d.frame != nil {
+		frame := *d.frame
+		frame.start.offset = rewriteExpr(f, frame.start.offset)
+		frame.end.offset = rewriteExpr(f, frame.end.offset)
+		d.frame = &frame
+	}
+	return d
+}
+
+func (e asExpr) rewrite(f func(Expression) Expression) Expression {
+	return asExpr{expr: rewriteExpr(f, e.expr), alias: e.alias}
+}
+
+func (e *ValuesTableExpr) rewrite(f func(Expression) Expression) Expression {
+	rows := make([]Values, len(e.rows))
+	for i := range e.rows {
+		rows[i] = rewriteExprs(f, e.rows[i])
+	}
+	return &ValuesTableExpr{rows: rows}
+}
+
+func (e valuesAliasExpr) rewrite(f func(Expression) Expression) Expression {
+	return valuesAliasExpr{values: rewriteExpr(f, e.values), alias: e.alias, columns: e.columns}
+}
+
+func (e lateralExpr) rewrite(f func(Expression) Expression) Expression {
+	return lateralExpr{asExpr: asExpr{expr: rewriteExpr(f, e.expr), alias: e.alias}}
+}
+
+func (e *fromItemExpr) rewrite(f func(Expression) Expression) Expression {
+	return &fromItemExpr{expr: rewriteExpr(f, e.expr)}
+}
+
+func (e *joinExpr) rewrite(f func(Expression) Expression) Expression {
+	return &joinExpr{
+		jointype: e.jointype,
+		left:     rewriteExpr(f, e.left),
+		right:    rewriteExpr(f, e.right),
+		on:       rewriteExpr(f, e.on),
+		using:    e.using,
+	}
+}
+
+func (o orderExpr) rewrite(f func(Expression) Expression) Expression {
+	o.expr = rewriteExpr(f, o.expr)
+	return o
+}
+
+func (g groupingElement) rewrite(f func(Expression) Expression) Expression {
+	return groupingElement{name: g.name, exprs: rewriteExprs(f, g.exprs)}
+}
+
+func (g groupingSet) rewrite(f func(Expression) Expression) Expression {
+	return rewriteExprs(f, g)
+}
+
+func (r *rawArgs) rewrite(f func(Expression) Expression) Expression {
+	return &rawArgs{s: r.s, args: rewriteExprs(f, r.args)}
+}
+
+// eachExpr calls f with each non-nil expression of exprs.
+func eachExpr(f func(Expression), exprs ...Expression) {
+	for i := range exprs {
+		if !isNil(exprs[i]) {
+			f(exprs[i])
+		}
+	}
+}
+
+func eachAssignment(f func(Expression), assignments []Assignment) {
+	for i := range assignments {
+		eachExpr(f, assignments[i].columnname, assignments[i].expr)
+	}
+}
+
+func (e *CTEs) eachCTE(f func(Expression)) {
+	if e == nil {
+		return
+	}
+	for i := range e.ctes {
+		eachExpr(f, e.ctes[i].stmt)
+	}
+}
+
+func (s *SelectStmt) children(f func(Expression)) {
+	s.ctes.eachCTE(f)
+	eachExpr(f, s.distincton...)
+	eachExpr(f, s.exprs...)
+	eachExpr(f, s.from...)
+	if s.where != nil {
+		eachExpr(f, s.where.Expression)
+	}
+	eachExpr(f, s.groupby...)
+	if s.having != nil {
+		eachExpr(f, s.having.Expression)
+	}
+	for i := range s.windows {
+		s.windows[i].def.eachDef(f)
+	}
+	eachExpr(f, s.unions...)
+	eachExpr(f, s.orderby...)
+	if s.limit != nil {
+		eachExpr(f, s.limit.Expression)
+	}
+	if s.offset != nil {
+		eachExpr(f, s.offset.Expression)
+	}
+	for i := range s.locking {
+		eachExpr(f, s.locking[i].of...)
+	}
+}
+
+func (c *CompoundStmt) children(f func(Expression)) {
+	c.ctes.eachCTE(f)
+	eachExpr(f, c.stmts...)
+	eachExpr(f, c.orderby...)
+	if c.limit != nil {
+		eachExpr(f, c.limit.Expression)
+	}
+	if c.offset != nil {
+		eachExpr(f, c.offset.Expression)
+	}
+}
+
+func (stmt *InsertStmt) children(f func(Expression)) {
+	stmt.ctes.eachCTE(f)
+	eachExpr(f, stmt.table)
+	eachExpr(f, stmt.columns...)
+	eachExpr(f, stmt.valueslist)
+	if stmt.onconflict != nil {
+		eachExpr(f, stmt.onconflict.target)
+		eachAssignment(f, stmt.onconflict.action.values)
+		eachExpr(f, stmt.onconflict.action.where)
+	}
+	eachExpr(f, stmt.returning...)
+}
+
+func (stmt *UpdateStmt) children(f func(Expression)) {
+	stmt.ctes.eachCTE(f)
+	eachExpr(f, stmt.table)
+	eachAssignment(f, stmt.assignments)
+	eachExpr(f, stmt.from...)
+	if stmt.where != nil {
+		eachExpr(f, stmt.where.Expression)
+	}
+	eachExpr(f, stmt.returning...)
+}
+
+func (stmt *DeleteStmt) children(f func(Expression)) {
+	stmt.ctes.eachCTE(f)
+	eachExpr(f, stmt.table)
+	eachExpr(f, stmt.using...)
+	if stmt.where != nil {
+		eachExpr(f, stmt.where.Expression)
+	}
+	eachExpr(f, stmt.returning...)
+}
+
+func (stmt *MergeStmt) children(f func(Expression)) {
+	stmt.ctes.eachCTE(f)
+	eachExpr(f, stmt.table, stmt.using, stmt.on)
+	for i := range stmt.whens {
+		eachExpr(f, stmt.whens[i].condition)
+		eachAssignment(f, stmt.whens[i].assignments)
+		eachExpr(f, stmt.whens[i].columns...)
+		eachExpr(f, stmt.whens[i].values...)
+	}
+}
+
+func (e valueslistexpr) children(f func(Expression)) {
+	for i := range e.valueslist {
+		eachExpr(f, e.valueslist[i]...)
+	}
+}
+
+func (e queryexpr) children(f func(Expression)) { eachExpr(f, e.query) }
+
+func (e ConflictTargetExpr) children(f func(Expression)) {
+	eachExpr(f, e.exprs...)
+	eachExpr(f, e.where)
+}
+
+func (v Values) children(f func(Expression)) { eachExpr(f, v...) }
+
+func (i *InfixExpr) children(f func(Expression)) { eachExpr(f, i.left, i.right) }
+
+func (e *keywordExpr) children(f func(Expression)) { eachExpr(f, e.expr) }
+
+func (e *betweenExpr) children(f func(Expression)) { eachExpr(f, e.expr, e.low, e.high) }
+
+func (e callExpr) children(f func(Expression)) { eachExpr(f, e.args...) }
+
+func (p *parenExpr) children(f func(Expression)) { eachExpr(f, p.expr) }
+
+func (r *rawArgs) children(f func(Expression)) { eachExpr(f, r.args...) }
+
+func (a aggrExpression) children(f func(Expression)) {
+	eachExpr(f, a.exprs...)
+	eachExpr(f, a.orderby, a.filterwhere)
+}
+
+func (c CaseExpr) children(f func(Expression)) {
+	for i := range c.whens {
+		eachExpr(f, c.whens[i].condition, c.whens[i].result)
+	}
+	eachExpr(f, c.elseresult)
+}
+
+func (w WindowFunctionExpr) children(f func(Expression)) {
+	eachExpr(f, w.args...)
+	eachExpr(f, w.filterwhere)
+	if w.over != nil {
+		w.over.eachDef(f)
+	}
+}
+
+func (d WindowDefinition) eachDef(f func(Expression)) {
+	eachExpr(f, d.partitionby...)
+	eachExpr(f, d.orderby...)
+	if d.frame != nil {
+		eachExpr(f, d.frame.start.offset, d.frame.end.offset)
+	}
+}
+
+func (e asExpr) children(f func(Expression)) { eachExpr(f, e.expr) }
+
+func (e *ValuesTableExpr) children(f func(Expression)) {
+	for i := range e.rows {
+		eachExpr(f, e.rows[i]...)
+	}
+}
+
+func (e valuesAliasExpr) children(f func(Expression)) { eachExpr(f, e.values) }
+
+func (e lateralExpr) children(f func(Expression)) { eachExpr(f, e.expr) }
+
+func (e *fromItemExpr) children(f func(Expression)) { eachExpr(f, e.expr) }
+
+func (e *joinExpr) children(f func(Expression)) { eachExpr(f, e.left, e.right, e.on) }
+
+func (o orderExpr) children(f func(Expression)) { eachExpr(f, o.expr) }
+
+func (g groupingElement) children(f func(Expression)) { eachExpr(f, g.exprs...) }
+
+func (g groupingSet) children(f func(Expression)) { eachExpr(f, g...) }
@@ -0,0 +1,111 @@
+package build
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestWalkTables(t *testing.T) {
+	stmt := With("recent", Select(Ident("user_id")).From(Ident("events"))).
+		Select(Star).
+		From(
+			FromItem(FromExpr(Ident("users")).As("u")).
+				Join(Ident("recent")).On(Ident("recent.user_id").Equal(Ident("u.id"))),
+		).
+		Where(Ident("u.org_id").In(Select(Ident("id")).From(Ident("orgs"))))
+
+	var tables []string
+	var fromitem func(Expression)
+	fromitem = func(item Expression) {
+		if name, ok := IdentName(item); ok {
+			tables = append(tables, name)
+		} else if left, right, _, ok := JoinParts(item); ok {
+			fromitem(left)
+			fromitem(right)
+		} else if aliased, _, ok := AliasParts(item); ok {
+			fromitem(aliased)
+		}
+	}
+	Walk(stmt, func(expr Expression) bool {
+		if s, ok := expr.(*SelectStmt); ok {
+			for _, item := range s.FromItems() {
+				fromitem(item)
+			}
+		}
+		return true
+	})
+	sort.Strings(tables)
+	expected := []string{"events", "orgs", "recent", "users"}
+	assertf(t, reflect.DeepEqual(tables, expected), "expected %v, got %v", expected, tables)
+}
+
+func TestWalkSkip(t *testing.T) {
+	var visited int
+	Walk(Select(Ident("a")).Where(Ident("b").Equal(Bind(1))), func(expr Expression) bool {
+		visited++
+		_, isstmt := expr.(*SelectStmt)
+		return isstmt
+	})
+	assertf(t, visited == 3, "expected 3 visited expressions, got %d", visited)
+}
+
+func TestRewriteTenant(t *testing.T) {
+	stmt := Select(Star).
+		From(Ident("users")).
+		Where(Ident("id").In(Select(Ident("user_id")).From(Ident("memberships"))))
+	tenant := func(expr Expression) Expression {
+		if s, ok := expr.(*SelectStmt); ok {
+			return s.AndWhere(Ident("tenant_id").Equal(Param("tenant_id")))
+		}
+		return expr
+	}
+	rewritten := Rewrite(stmt, tenant).(*SelectStmt)
+
+	out, _ := rewritten.Build()
+	expected := `SELECT * FROM "users" WHERE "id" IN (SELECT "user_id" FROM "memberships" WHERE "tenant_id" = $1) AND "tenant_id" = $2`
+	assertf(t, out == expected, "expected %q, got %q", expected, out)
+
+	out, _ = stmt.Build()
+	expected = `SELECT * FROM "users" WHERE "id" IN (SELECT "user_id" FROM "memberships")`
+	assertf(t, out == expected, "expected the original statement to be unchanged, got %q", out)
+}
+
+func TestRewriteRedact(t *testing.T) {
+	stmt := Update("users").
+		Set(Assign("password", Bind("hunter2"))).
+		Where(Ident("email").Equal(Bind("foo@example.com")).
+			And(Ident("id").Equal(Int(1))).
+			And(RawArgs("lower(?) = ?", "name", "foo")).
+			And(Ident("token").Equal(String("secret"))))
+	redacted := Rewrite(stmt, func(expr Expression) Expression {
+		if _, ok := BindValue(expr); ok {
+			return Bind("[redacted]")
+		}
+		if _, ok := StringValue(expr); ok {
+			return String("[redacted]")
+		}
+		return expr
+	}).(*UpdateStmt)
+
+	out, args := redacted.Build()
+	expected := `UPDATE "users" SET "password" = $1 WHERE "email" = $2 AND "id" = 1 AND (lower($3) = $4) AND "token" = '[redacted]'`
+	assertf(t, out == expected, "expected %q, got %q", expected, out)
+	assertf(t, reflect.DeepEqual(args, []interface{}{"[redacted]", "[redacted]", "[redacted]", "[redacted]"}), "got %v", args)
+
+	op, left, right, ok := OperatorParts(redacted.WhereCondition())
+	assertf(t, ok && op == "AND", "expected an AND operator, got %q", op)
+	assertf(t, left != nil && right != nil, "expected operands")
+}
+
+func TestWalkAllocs(t *testing.T) {
+	stmt := With("recent", Select(Ident("user_id")).From(Ident("events"))).
+		Select(Star).
+		From(FromItem(Ident("users")).Join(Ident("recent")).On(Ident("recent.user_id").Equal(Ident("users.id")))).
+		Where(Ident("users.org_id").In(Select(Ident("id")).From(Ident("orgs"))))
+	var visited int
+	fn := func(Expression) bool { visited++; return true }
+	allocs := testing.AllocsPerRun(10, func() { Walk(stmt, fn) })
+	// Walk allocates its recursive closure, and never copies stmt.
+	assertf(t, allocs <= 2, "expected at most 2 allocations, got %v", allocs)
+}
@@ -7,9 +7,17 @@ func WindowFunction(function string, args ...Expression) WindowFunctionExpr {
 
 // A WindowFunctionExpr is a window function expression.
 type WindowFunctionExpr struct {
-	function string
-	args     []Expression
-	over     *WindowDefinition
+	function    string
+	args        []Expression
+	filterwhere Expression
+	over        *WindowDefinition
+	overname    identifier
+}
+
+// FilterWhere adds a FILTER clause to w.
+func (w WindowFunctionExpr) FilterWhere(expr Expression) WindowFunctionExpr {
+	w.filterwhere = expr
+	return w
 }
 
 // Over sets the window defintion of w.
@@ -18,6 +26,13 @@ func (w WindowFunctionExpr) Over(def WindowDefinition) Expression {
 	return w
 }
 
+// OverWindow sets the window of w to the named window name, defined with
+// SelectStmt.Window.
+func (w WindowFunctionExpr) OverWindow(name string) Expression {
+	w.overname = identifier(name)
+	return w
+}
+
 func (w WindowFunctionExpr) build(b *builder) {
 	b.write(w.function)
 	b.write("(")
@@ -25,9 +40,20 @@ func (w WindowFunctionExpr) build(b *builder) {
 		if i > 0 {
 			b.write(", ")
 		}
-		arg.build(b)
+		buildOperand(b, arg, false)
+	}
+	b.write(")")
+	if !isNil(w.filterwhere) {
+		b.write(" FILTER (WHERE ")
+		w.filterwhere.build(b)
+		b.write(")")
+	}
+	if w.overname != "" {
+		b.write(" OVER ")
+		w.overname.build(b)
+		return
 	}
-	b.write(") OVER (")
+	b.write(" OVER (")
 	if w.over != nil {
 		b.write(" ")
 		w.over.build(b)
@@ -36,9 +62,15 @@ func (w WindowFunctionExpr) build(b *builder) {
 	b.write(")")
 }
 
-// PartitionBy returns a window definition.
-func PartitionBy(expr Expression) WindowDefinition {
-	return WindowDefinition{partitionby: expr}
+// ExistingWindow returns a window definition based on the named window name,
+// defined with SelectStmt.Window.
+func ExistingWindow(name string) WindowDefinition {
+	return WindowDefinition{name: identifier(name)}
+}
+
+// PartitionBy returns a window definition. Nil exprs are skipped.
+func PartitionBy(exprs ...Expression) WindowDefinition {
+	return WindowDefinition{partitionby: nonNil(exprs)}
 }
 
 // OrderBy returns a window definition.
@@ -48,8 +80,28 @@ func OrderBy(exprs ...Expression) WindowDefinition {
 
 // A WindowDefinition is a window definition.
 type WindowDefinition struct {
-	partitionby Expression
+	name        identifier
+	partitionby []Expression
 	orderby     orderby
+	frame       *frameClause
+}
+
+// PartitionBy adds a PARTITION BY clause to d. Nil exprs are skipped, and d
+// has no PARTITION BY clause if all exprs are nil.
+func (d WindowDefinition) PartitionBy(exprs ...Expression) WindowDefinition {
+	d.partitionby = nonNil(exprs)
+	return d
+}
+
+// nonNil returns the non-nil exprs, or nil if all exprs are nil.
+func nonNil(exprs []Expression) []Expression {
+	var nonnil []Expression
+	for _, expr := range exprs {
+		if !isNil(expr) {
+			nonnil = append(nonnil, expr)
+		}
+	}
+	return nonnil
 }
 
 // OrderBy adds an ORDER BY clause to d.
@@ -58,17 +110,154 @@ func (d WindowDefinition) OrderBy(exprs ...Expression) WindowDefinition {
 	return d
 }
 
+// Rows adds a ROWS frame clause to d.
+func (d WindowDefinition) Rows(start, end FrameBound) WindowDefinition {
+	return d.withFrame("ROWS", start, end)
+}
+
+// Range adds a RANGE frame clause to d.
+func (d WindowDefinition) Range(start, end FrameBound) WindowDefinition {
+	return d.withFrame("RANGE", start, end)
+}
+
+// Groups adds a GROUPS frame clause to d.
+func (d WindowDefinition) Groups(start, end FrameBound) WindowDefinition {
+	return d.withFrame("GROUPS", start, end)
+}
+
+// withFrame sets the frame clause of d, keeping its frame exclusion.
+func (d WindowDefinition) withFrame(mode string, start, end FrameBound) WindowDefinition {
+	frame := &frameClause{mode: mode, start: start, end: end}
+	if d.frame != nil {
+		frame.exclusion = d.frame.exclusion
+	}
+	d.frame = frame
+	return d
+}
+
+// Exclude adds a frame exclusion to the frame clause of d.
+func (d WindowDefinition) Exclude(exclusion FrameExclusion) WindowDefinition {
+	if d.frame == nil {
+		d.frame = &frameClause{}
+	} else {
+		frame := *d.frame
+		d.frame = &frame
+	}
+	d.frame.exclusion = &exclusion
+	return d
+}
+
 func (d WindowDefinition) build(b *builder) {
-	if d.partitionby != nil {
-		b.write("PARTITION BY ")
-		d.partitionby.build(b)
+	var clauses int
+	separate := func() {
+		if clauses > 0 {
+			b.write(" ")
+		}
+		clauses++
 	}
 
-	if d.partitionby != nil && d.orderby != nil {
-		b.write(" ")
+	if d.name != "" {
+		separate()
+		d.name.build(b)
+	}
+
+	if d.partitionby != nil {
+		separate()
+		b.write("PARTITION BY ")
+		for i := range d.partitionby {
+			if i > 0 {
+				b.write(", ")
+			}
+			d.partitionby[i].build(b)
+		}
 	}
 
 	if d.orderby != nil {
+		separate()
 		d.orderby.build(b)
 	}
+
+	if d.frame != nil {
+		separate()
+		d.frame.build(b)
+	}
+}
+
+type frameClause struct {
+	mode       string
+	start, end FrameBound
+	exclusion  *FrameExclusion
+}
+
+func (f frameClause) build(b *builder) {
+	if f.mode == "" {
+		b.errorf("frame exclusion without a frame clause")
+		return
+	}
+	b.write(f.mode)
+	b.write(" BETWEEN ")
+	f.start.build(b)
+	b.write(" AND ")
+	f.end.build(b)
+
+	if f.exclusion != nil {
+		switch e := *f.exclusion; e {
+		case ExcludeCurrentRow:
+			b.write(" EXCLUDE CURRENT ROW")
+		case ExcludeGroup:
+			b.write(" EXCLUDE GROUP")
+		case ExcludeTies:
+			b.write(" EXCLUDE TIES")
+		case ExcludeNoOthers:
+			b.write(" EXCLUDE NO OTHERS")
+		default:
+			b.errorf("unknown frame exclusion %d", e)
+		}
+	}
+}
+
+// A FrameBound is the start or the end of a window frame.
+type FrameBound struct {
+	offset Expression
+	kind   string
+}
+
+// Preceding returns the offset PRECEDING frame bound.
+func Preceding(offset Expression) FrameBound {
+	return FrameBound{offset: offset, kind: "PRECEDING"}
+}
+
+// Following returns the offset FOLLOWING frame bound.
+func Following(offset Expression) FrameBound {
+	return FrameBound{offset: offset, kind: "FOLLOWING"}
+}
+
+// FrameBound values.
+var (
+	UnboundedPreceding = FrameBound{kind: "UNBOUNDED PRECEDING"}
+	CurrentRow         = FrameBound{kind: "CURRENT ROW"}
+	UnboundedFollowing = FrameBound{kind: "UNBOUNDED FOLLOWING"}
+)
+
+func (f FrameBound) build(b *builder) {
+	if f.kind == "" {
+		b.errorf("empty frame bound")
+		return
+	}
+	if f.offset != nil {
+		f.offset.build(b)
+		b.write(" ")
+	}
+	b.write(f.kind)
 }
+
+// A FrameExclusion is a frame exclusion option.
+type FrameExclusion int
+
+// FrameExclusion values.
+const (
+	ExcludeCurrentRow FrameExclusion = iota
+	ExcludeGroup
+	ExcludeTies
+	ExcludeNoOthers
+)
@@ -41,6 +41,55 @@ func TestWindowFunction(t *testing.T) {
 		).
 			From(Ident("empsalary")),
 		out: `SELECT "salary", sum("salary") OVER ( ORDER BY "salary" ) FROM "empsalary"`,
+	}, {
+		stmt: Select(
+			Ident("salary"),
+			WindowFunction("rank").Over(PartitionBy(Ident("depname"), Ident("location")).OrderBy(Ident("salary"))),
+		).
+			From(Ident("empsalary")),
+		out: `SELECT "salary", rank() OVER ( PARTITION BY "depname", "location" ORDER BY "salary" ) FROM "empsalary"`,
+	}, {
+		stmt: Select(
+			WindowFunction("rank").Over(PartitionBy(nil, Ident("depname"), (*InfixExpr)(nil)).OrderBy(Ident("salary"))),
+			WindowFunction("rank").Over(OrderBy(Ident("salary")).PartitionBy(nil)),
+		).
+			From(Ident("empsalary")),
+		out: `SELECT rank() OVER ( PARTITION BY "depname" ORDER BY "salary" ), rank() OVER ( ORDER BY "salary" ) FROM "empsalary"`,
+	}, {
+		stmt: Select(
+			Ident("day"),
+			WindowFunction("avg", Ident("amount")).Over(OrderBy(Ident("day")).Rows(Preceding(Int(6)), CurrentRow)),
+			WindowFunction("sum", Ident("amount")).Over(OrderBy(Ident("day")).Range(UnboundedPreceding, CurrentRow).Exclude(ExcludeTies)),
+			WindowFunction("count", Star).Over(OrderBy(Ident("day")).Groups(Preceding(Bind(1)), Following(Bind(1)))),
+		).
+			From(Ident("sales")),
+		out:  `SELECT "day", avg("amount") OVER ( ORDER BY "day" ROWS BETWEEN 6 PRECEDING AND CURRENT ROW ), sum("amount") OVER ( ORDER BY "day" RANGE BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW EXCLUDE TIES ), count(*) OVER ( ORDER BY "day" GROUPS BETWEEN $1 PRECEDING AND $2 FOLLOWING ) FROM "sales"`,
+		args: []interface{}{1, 1},
+	}, {
+		stmt: Select(
+			WindowFunction("sum", Ident("salary")).OverWindow("w"),
+			WindowFunction("avg", Ident("salary")).Over(ExistingWindow("w").Rows(UnboundedPreceding, UnboundedFollowing)),
+		).
+			From(Ident("empsalary")).
+			Window("w", PartitionBy(Ident("depname")).OrderBy(Order(Ident("salary"), Desc))),
+		out: `SELECT sum("salary") OVER "w", avg("salary") OVER ( "w" ROWS BETWEEN UNBOUNDED PRECEDING AND UNBOUNDED FOLLOWING ) FROM "empsalary" WINDOW "w" AS ( PARTITION BY "depname" ORDER BY "salary" DESC )`,
+	}, {
+		stmt: Select(
+			WindowFunction("sum", Ident("amount")).
+				FilterWhere(Ident("kind").Equal(Bind("credit"))).
+				Over(OrderBy(Ident("day"))),
+		).
+			From(Ident("transactions")),
+		out:  `SELECT sum("amount") FILTER (WHERE "kind" = $1) OVER ( ORDER BY "day" ) FROM "transactions"`,
+		args: []interface{}{"credit"},
+	}, {
+		stmt: Select(
+			WindowFunction("sum", Ident("amount")).
+				FilterWhere(And()).
+				Over(OrderBy(Ident("day")).Exclude(ExcludeTies).Rows(UnboundedPreceding, CurrentRow)),
+		).
+			From(Ident("transactions")),
+		out: `SELECT sum("amount") OVER ( ORDER BY "day" ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW EXCLUDE TIES ) FROM "transactions"`,
 	}} {
 		t.Run(tt.out, func(t *testing.T) {
 			out, args := tt.stmt.Build()
@@ -27,6 +27,7 @@ type FindOptions struct {
 	orders []build.Expression
 	limit  *int
 	offset *int
+	lock   []build.LockingClause
 }
 
 type FindOptionsJoin struct {
@@ -41,6 +42,9 @@ func WithLimit(limit int) FindOption {
 func WithOffset(offset int) FindOption {
 	return func(o *FindOptions) { o.offset = &offset }
 }
+func WithLock(clauses ...build.LockingClause) FindOption {
+	return func(o *FindOptions) { o.lock = clauses }
+}
 func WithJoins(joins []FindOptionsJoin) FindOption {
 	return func(o *FindOptions) { o.joins = joins }
 }
@@ -92,7 +96,13 @@ func find[
 	if opts.offset != nil {
 		stmt = stmt.Offset(build.Bind(*opts.offset))
 	}
-	query, args := stmt.Build()
+	if opts.lock != nil {
+		stmt = stmt.For(opts.lock...)
+	}
+	query, args, err := stmt.BuildErr()
+	if err != nil {
+		return err
+	}
 
 	rows, err := db.QueryContext(ctx, query, args...)
 	if err != nil {
@@ -4,7 +4,10 @@ import (
 	"context"
 	"database/sql"
 	"database/sql/driver"
+	"errors"
 	"testing"
+
+	"github.com/yansal/sql/build"
 )
 
 func TestFind(t *testing.T) {
@@ -39,3 +42,40 @@ func TestFind(t *testing.T) {
 	assertequal(t, id2, ms[1].ID)
 	assertequal(t, name2, ms[1].Name)
 }
+
+func TestFindWithLock(t *testing.T) {
+	ctx := context.Background()
+	queryfunc := func(values []driver.Value) (driver.Rows, error) {
+		assertlen(t, values, 1)
+		if values[0] != int64(10) {
+			t.Errorf("expected limit 10, got %v", values[0])
+		}
+		return &mockRows{columns: []string{"id", "name", "pm_id"}}, nil
+	}
+	preparefunc := func(query string) (driver.Stmt, error) {
+		assertequal(t, `SELECT "model"."id", "model"."name", "model"."pm_id" FROM "model" LIMIT $1 FOR UPDATE SKIP LOCKED`, query)
+		return &mockStmt{queryfunc: queryfunc}, nil
+	}
+	db := sql.OpenDB(&mockConnector{conn: &mockConn{preparefunc: preparefunc}})
+
+	ms, err := Find[M](ctx, db, WithLimit(10), WithLock(build.ForUpdate().SkipLocked()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertlen(t, ms, 0)
+}
+
+func TestFindBuildError(t *testing.T) {
+	ctx := context.Background()
+	preparefunc := func(query string) (driver.Stmt, error) {
+		t.Errorf("unexpected query %q", query)
+		return nil, nil
+	}
+	db := sql.OpenDB(&mockConnector{conn: &mockConn{preparefunc: preparefunc}})
+
+	_, err := Find[M](ctx, db, WithLock(build.ForUpdate().NoWait().SkipLocked()))
+	var buildErr *build.BuildError
+	if !errors.As(err, &buildErr) {
+		t.Errorf("expected a *build.BuildError, got %v", err)
+	}
+}
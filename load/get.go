@@ -29,10 +29,13 @@ func get(ctx context.Context, db Querier, dest Model, where build.Expression) er
 		columns = dest.GetColumns()
 		table   = dest.GetTable()
 	)
-	query, args := build.Select(build.Columns(columns...)...).
+	query, args, err := build.Select(build.Columns(columns...)...).
 		From(build.Ident(table)).
 		Where(where).
-		Build()
+		BuildErr()
+	if err != nil {
+		return err
+	}
 
 	rows, err := db.QueryContext(ctx, query, args...)
 	if err != nil {
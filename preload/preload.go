@@ -304,7 +304,10 @@ func (q *query) do(ctx context.Context, db Querier) (*results, error) {
 		if q.orderby != nil {
 			stmt = stmt.OrderBy(q.orderby...)
 		}
-		query, args := stmt.Build()
+		query, args, err := stmt.BuildErr()
+		if err != nil {
+			return nil, err
+		}
 
 		rows, err := db.QueryContext(ctx, query, args...)
 		if err != nil {